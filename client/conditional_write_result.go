@@ -0,0 +1,50 @@
+package client
+
+import "fmt"
+
+type ConditionalWriteResult struct {
+	status              ConditionalWriteStatus
+	nextExpectedVersion int
+	logPosition         *Position
+	currentVersion      *int
+}
+
+// currentVersion is nil when the server didn't report the current version of the stream
+func NewConditionalWriteResult(
+	status ConditionalWriteStatus,
+	nextExpectedVersion int,
+	logPosition *Position,
+	currentVersion *int,
+) *ConditionalWriteResult {
+	return &ConditionalWriteResult{
+		status:              status,
+		nextExpectedVersion: nextExpectedVersion,
+		logPosition:         logPosition,
+		currentVersion:      currentVersion,
+	}
+}
+
+func (r *ConditionalWriteResult) Status() ConditionalWriteStatus { return r.status }
+
+// Only meaningful when Status() is ConditionalWriteStatus_Succeeded
+func (r *ConditionalWriteResult) NextExpectedVersion() int { return r.nextExpectedVersion }
+
+// Only meaningful when Status() is ConditionalWriteStatus_Succeeded
+func (r *ConditionalWriteResult) LogPosition() *Position { return r.logPosition }
+
+// Version of the stream when the write was attempted. The second value is false when the server didn't report it.
+func (r *ConditionalWriteResult) CurrentVersion() (int, bool) {
+	if r.currentVersion == nil {
+		return 0, false
+	}
+	return *r.currentVersion, true
+}
+
+func (r *ConditionalWriteResult) String() string {
+	currentVersion := "<nil>"
+	if r.currentVersion != nil {
+		currentVersion = fmt.Sprint(*r.currentVersion)
+	}
+	return fmt.Sprintf("&{status:%s nextExpectedVersion:%d logPosition:%+v currentVersion:%s}", r.status,
+		r.nextExpectedVersion, r.logPosition, currentVersion)
+}
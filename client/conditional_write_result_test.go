@@ -0,0 +1,22 @@
+package client_test
+
+import (
+	"github.com/jdextraze/go-gesclient/client"
+	"testing"
+)
+
+func TestConditionalWriteResult_CurrentVersion(t *testing.T) {
+	r := client.NewConditionalWriteResult(client.ConditionalWriteStatus_VersionMismatch, -1, nil, nil)
+	if _, ok := r.CurrentVersion(); ok {
+		t.Error("CurrentVersion should not be available")
+	}
+	if r.String() != "&{status:VersionMismatch nextExpectedVersion:-1 logPosition:<nil> currentVersion:<nil>}" {
+		t.Errorf("String doesn't match: %s", r)
+	}
+
+	version := 3
+	r = client.NewConditionalWriteResult(client.ConditionalWriteStatus_VersionMismatch, -1, nil, &version)
+	if current, ok := r.CurrentVersion(); !ok || current != 3 {
+		t.Errorf("CurrentVersion doesn't match: %d, %t != 3, true", current, ok)
+	}
+}
@@ -0,0 +1,19 @@
+package client
+
+type ConditionalWriteStatus int
+
+const (
+	ConditionalWriteStatus_Succeeded       ConditionalWriteStatus = 0
+	ConditionalWriteStatus_VersionMismatch ConditionalWriteStatus = 1
+	ConditionalWriteStatus_StreamDeleted   ConditionalWriteStatus = 2
+)
+
+var conditionalWriteStatuses = map[int]string{
+	0: "Succeeded",
+	1: "VersionMismatch",
+	2: "StreamDeleted",
+}
+
+func (s ConditionalWriteStatus) String() string {
+	return conditionalWriteStatuses[int(s)]
+}
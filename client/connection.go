@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"github.com/jdextraze/go-gesclient/tasks"
 	"time"
 )
@@ -27,6 +28,10 @@ type Connection interface {
 
 	Close() error
 
+	// Stops accepting new operations and subscriptions, waits for the active operations to complete and unsubscribes
+	// the subscriptions before closing the connection. The connection is closed even when ctx is done first.
+	CloseGracefully(ctx context.Context) error
+
 	// Task.Result() returns *client.DeleteResult
 	DeleteStreamAsync(stream string, expectedVersion int, hardDelete bool, userCredentials *UserCredentials) (
 		*tasks.Task, error)
@@ -35,6 +40,26 @@ type Connection interface {
 	AppendToStreamAsync(stream string, expectedVersion int, events []*EventData, userCredentials *UserCredentials) (
 		*tasks.Task, error)
 
+	// Appends events in a single write when their total size is at most maxBatchBytes, otherwise in a transaction
+	// written in batches of at most maxBatchBytes. Task.Result() returns *client.WriteResult
+	AppendLargeAsync(stream string, expectedVersion int, events []*EventData, maxBatchBytes int,
+		userCredentials *UserCredentials) (*tasks.Task, error)
+
+	// Appends links to the events to targetStream, see NewLinkEventData.
+	// Task.Result() returns *client.WriteResult
+	LinkToAsync(targetStream string, expectedVersion int, events []*RecordedEvent, userCredentials *UserCredentials) (
+		*tasks.Task, error)
+
+	// Appends events having deterministic ids, see NewDeterministicEventId. Writing again the last events of the stream
+	// succeeds and is reported as a duplicate. Task.Result() returns *client.IdempotentWriteResult
+	AppendIdempotentAsync(stream string, expectedVersion int, events []*EventData, userCredentials *UserCredentials) (
+		*tasks.Task, error)
+
+	// Same as AppendToStreamAsync, but a version mismatch or a deleted stream is reported in the result instead of as an
+	// error. Task.Result() returns *client.ConditionalWriteResult
+	ConditionalAppendToStreamAsync(stream string, expectedVersion int, events []*EventData,
+		userCredentials *UserCredentials) (*tasks.Task, error)
+
 	// Task.Result() returns *client.Transaction
 	StartTransactionAsync(stream string, expectedVersion int, userCredentials *UserCredentials) (
 		*tasks.Task, error)
@@ -61,6 +86,28 @@ type Connection interface {
 	ReadAllEventsBackwardAsync(pos *Position, max int, resolveTos bool, userCredentials *UserCredentials) (
 		*tasks.Task, error)
 
+	// Reads the $ce-<category> stream, resolving the links to the events. The Event() of a link to a deleted event is
+	// nil, see ResolvedEvent.IsLinkToDeletedEvent.
+	// Task.Result() returns *client.StreamEventsSlice
+	ReadCategoryEventsForwardAsync(category string, start int, max int, userCredentials *UserCredentials) (
+		*tasks.Task, error)
+
+	// Same as ReadCategoryEventsForwardAsync, from the end of the stream
+	// Task.Result() returns *client.StreamEventsSlice
+	ReadCategoryEventsBackwardAsync(category string, start int, max int, userCredentials *UserCredentials) (
+		*tasks.Task, error)
+
+	// Reads the $et-<eventType> stream, resolving the links to the events. The Event() of a link to a deleted event is
+	// nil, see ResolvedEvent.IsLinkToDeletedEvent.
+	// Task.Result() returns *client.StreamEventsSlice
+	ReadEventTypeEventsForwardAsync(eventType string, start int, max int, userCredentials *UserCredentials) (
+		*tasks.Task, error)
+
+	// Same as ReadEventTypeEventsForwardAsync, from the end of the stream
+	// Task.Result() returns *client.StreamEventsSlice
+	ReadEventTypeEventsBackwardAsync(eventType string, start int, max int, userCredentials *UserCredentials) (
+		*tasks.Task, error)
+
 	// Task.Result() returns client.EventStoreSubscription
 	SubscribeToStreamAsync(
 		stream string,
@@ -108,6 +155,30 @@ type Connection interface {
 		userCredentials *UserCredentials,
 	) (CatchUpSubscription, error)
 
+	// Subscribes to the $ce-<category> stream. Links are always resolved, whatever the settings, and the Event() of a
+	// link to a deleted event is nil. lastCheckpoint is an event number of the $ce-<category> stream, as returned by
+	// ResolvedEvent.OriginalEventNumber.
+	SubscribeToCategoryFrom(
+		category string,
+		lastCheckpoint *int,
+		settings *CatchUpSubscriptionSettings,
+		eventAppeared CatchUpEventAppearedHandler,
+		liveProcessingStarted LiveProcessingStartedHandler,
+		subscriptionDropped CatchUpSubscriptionDroppedHandler,
+		userCredentials *UserCredentials,
+	) (CatchUpSubscription, error)
+
+	// Same as SubscribeToCategoryFrom for the $et-<eventType> stream
+	SubscribeToEventTypeFrom(
+		eventType string,
+		lastCheckpoint *int,
+		settings *CatchUpSubscriptionSettings,
+		eventAppeared CatchUpEventAppearedHandler,
+		liveProcessingStarted LiveProcessingStartedHandler,
+		subscriptionDropped CatchUpSubscriptionDroppedHandler,
+		userCredentials *UserCredentials,
+	) (CatchUpSubscription, error)
+
 	// Task.Result() returns *client.PersistentSubscriptionUpdateResult
 	UpdatePersistentSubscriptionAsync(stream string, groupName string, settings *PersistentSubscriptionSettings,
 		userCredentials *UserCredentials) (*tasks.Task, error)
@@ -127,6 +198,12 @@ type Connection interface {
 	// Task.Result() returns *client.StreamMetadataResult
 	GetStreamMetadataAsync(stream string, userCredentials *UserCredentials) (*tasks.Task, error)
 
+	// Reads the stream metadata, applies update and writes the result expecting the metastream version that was read.
+	// It starts over when another writer changed the metadata in between, up to MaxRetries times.
+	// Task.Result() returns *client.WriteResult
+	UpdateStreamMetadataAsync(stream string, update func(metadata StreamMetadata) StreamMetadata,
+		userCredentials *UserCredentials) (*tasks.Task, error)
+
 	// Task.Result() returns *client.WriteResult
 	SetSystemSettings(settings *SystemSettings, userCredentials *UserCredentials) (*tasks.Task, error)
 
@@ -143,6 +220,12 @@ type Connection interface {
 	AuthenticationFailed() EventHandlers
 
 	Settings() *ConnectionSettings
+
+	// Number of packages waiting to be written to the current TCP connection
+	SendQueueLength() int
+
+	// Snapshot of the connection state, suitable for health checks. It never blocks, even when the connection is busy.
+	State() *ConnectionState
 }
 
 type CatchUpSubscription interface {
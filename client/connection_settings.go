@@ -1,6 +1,7 @@
 package client
 
 import (
+	"crypto/tls"
 	"time"
 )
 
@@ -20,6 +21,7 @@ type ConnectionSettings struct {
 	useSslConnection            bool
 	targetHost                  string
 	validateServer              bool
+	tlsConfig                   *tls.Config
 	failOnNoServerResponse      bool
 	heartbeatInterval           time.Duration
 	heartbeatTimeout            time.Duration
@@ -29,6 +31,13 @@ type ConnectionSettings struct {
 	gossipSeeds                 []*GossipSeed
 	gossipTimeout               time.Duration
 	clientConnectionTimeout     time.Duration
+	sendQueueSize               int
+	sendQueueOverflowPolicy     SendQueueOverflowPolicy
+	sendQueueTimeout            time.Duration
+	reconnectionStrategy        ReconnectionStrategy
+	retryPolicy                 RetryPolicy
+	retryPolicies               map[Command]RetryPolicy
+	credentialsProvider         CredentialsProvider
 }
 
 func newConnectionSettings(
@@ -45,6 +54,7 @@ func newConnectionSettings(
 	useSslConnection bool,
 	targetHost string,
 	validateService bool,
+	tlsConfig *tls.Config,
 	failOnNoServerResponse bool,
 	heartbeatInterval time.Duration,
 	heartbeatTimeout time.Duration,
@@ -54,6 +64,13 @@ func newConnectionSettings(
 	gossipSeeds []*GossipSeed,
 	gossipTimeout time.Duration,
 	clientConnectionTimeout time.Duration,
+	sendQueueSize int,
+	sendQueueOverflowPolicy SendQueueOverflowPolicy,
+	sendQueueTimeout time.Duration,
+	reconnectionStrategy ReconnectionStrategy,
+	retryPolicy RetryPolicy,
+	retryPolicies map[Command]RetryPolicy,
+	credentialsProvider CredentialsProvider,
 ) *ConnectionSettings {
 	if maxQueueSize <= 0 {
 		panic("maxQueueSize should be positive")
@@ -67,6 +84,12 @@ func newConnectionSettings(
 	if maxReconnections < -1 {
 		panic("maxReconnections is out of range. Allowed range: [-1, infinity]")
 	}
+	if sendQueueSize <= 0 {
+		panic("sendQueueSize should be positive")
+	}
+	if sendQueueOverflowPolicy == SendQueueOverflowPolicy_Block && sendQueueTimeout <= 0 {
+		panic("sendQueueTimeout should be positive when blocking on a full send queue")
+	}
 	if useSslConnection && targetHost == "" {
 		panic("targetHost must be present")
 	}
@@ -84,6 +107,7 @@ func newConnectionSettings(
 		useSslConnection:            useSslConnection,
 		targetHost:                  targetHost,
 		validateServer:              validateService,
+		tlsConfig:                   tlsConfig,
 		failOnNoServerResponse:      failOnNoServerResponse,
 		heartbeatInterval:           heartbeatInterval,
 		heartbeatTimeout:            heartbeatTimeout,
@@ -93,6 +117,13 @@ func newConnectionSettings(
 		gossipSeeds:                 gossipSeeds,
 		gossipTimeout:               gossipTimeout,
 		clientConnectionTimeout:     clientConnectionTimeout,
+		sendQueueSize:               sendQueueSize,
+		sendQueueOverflowPolicy:     sendQueueOverflowPolicy,
+		sendQueueTimeout:            sendQueueTimeout,
+		reconnectionStrategy:        reconnectionStrategy,
+		retryPolicy:                 retryPolicy,
+		retryPolicies:               retryPolicies,
+		credentialsProvider:         credentialsProvider,
 	}
 }
 
@@ -144,6 +175,10 @@ func (cs *ConnectionSettings) ValidateService() bool {
 	return cs.validateServer
 }
 
+func (cs *ConnectionSettings) TlsConfig() *tls.Config {
+	return cs.tlsConfig
+}
+
 func (cs *ConnectionSettings) FailOnNoServerResponse() bool {
 	return cs.failOnNoServerResponse
 }
@@ -153,7 +188,7 @@ func (cs *ConnectionSettings) HeartbeatInterval() time.Duration {
 }
 
 func (cs *ConnectionSettings) HeartbeatTimeout() time.Duration {
-	return cs.heartbeatInterval
+	return cs.heartbeatTimeout
 }
 
 func (cs *ConnectionSettings) ClusterDns() string {
@@ -179,3 +214,39 @@ func (cs *ConnectionSettings) GossipTimeout() time.Duration {
 func (cs *ConnectionSettings) ClientConnectionTimeout() time.Duration {
 	return cs.clientConnectionTimeout
 }
+
+func (cs *ConnectionSettings) SendQueueSize() int {
+	return cs.sendQueueSize
+}
+
+func (cs *ConnectionSettings) SendQueueOverflowPolicy() SendQueueOverflowPolicy {
+	return cs.sendQueueOverflowPolicy
+}
+
+func (cs *ConnectionSettings) SendQueueTimeout() time.Duration {
+	return cs.sendQueueTimeout
+}
+
+// Defaults to a constant strategy using ReconnectionDelay()
+func (cs *ConnectionSettings) ReconnectionStrategy() ReconnectionStrategy {
+	if cs.reconnectionStrategy == nil {
+		return NewConstantReconnectionStrategy(cs.reconnectionDelay)
+	}
+	return cs.reconnectionStrategy
+}
+
+// Policy deciding whether operations sent with the given request command are retried
+func (cs *ConnectionSettings) RetryPolicy(command Command) RetryPolicy {
+	if policy, found := cs.retryPolicies[command]; found {
+		return policy
+	}
+	if cs.retryPolicy == nil {
+		return DefaultRetryPolicy
+	}
+	return cs.retryPolicy
+}
+
+// Takes precedence over DefaultUserCredentials when set
+func (cs *ConnectionSettings) CredentialsProvider() CredentialsProvider {
+	return cs.credentialsProvider
+}
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"net"
 	"time"
 )
@@ -19,6 +21,9 @@ type ConnectionSettingsBuilder struct {
 	useSslConnection            bool
 	targetHost                  string
 	validateServer              bool
+	tlsConfig                   *tls.Config
+	tlsRootCAs                  *x509.CertPool
+	tlsCertificates             []tls.Certificate
 	failOnNoServerResponse      bool
 	heartbeatInterval           time.Duration
 	heartbeatTimeout            time.Duration
@@ -28,6 +33,13 @@ type ConnectionSettingsBuilder struct {
 	gossipSeeds                 []*GossipSeed
 	gossipTimeout               time.Duration
 	clientConnectionTimeout     time.Duration
+	sendQueueSize               int
+	sendQueueOverflowPolicy     SendQueueOverflowPolicy
+	sendQueueTimeout            time.Duration
+	reconnectionStrategy        ReconnectionStrategy
+	retryPolicy                 RetryPolicy
+	retryPolicies               map[Command]RetryPolicy
+	credentialsProvider         CredentialsProvider
 }
 
 func CreateConnectionSettings() *ConnectionSettingsBuilder {
@@ -54,6 +66,9 @@ func CreateConnectionSettings() *ConnectionSettingsBuilder {
 		gossipSeeds:                 nil,
 		gossipTimeout:               1 * time.Second,
 		clientConnectionTimeout:     5 * time.Second,
+		sendQueueSize:               DefaultSendQueueSize,
+		sendQueueOverflowPolicy:     SendQueueOverflowPolicy_Fail,
+		sendQueueTimeout:            0,
 	}
 }
 
@@ -72,6 +87,7 @@ func ConnectionSettingsBuilderFrom(o *ConnectionSettings) *ConnectionSettingsBui
 		useSslConnection:            o.useSslConnection,
 		targetHost:                  o.targetHost,
 		validateServer:              o.validateServer,
+		tlsConfig:                   o.tlsConfig,
 		failOnNoServerResponse:      o.failOnNoServerResponse,
 		heartbeatInterval:           o.heartbeatInterval,
 		heartbeatTimeout:            o.heartbeatTimeout,
@@ -81,6 +97,13 @@ func ConnectionSettingsBuilderFrom(o *ConnectionSettings) *ConnectionSettingsBui
 		gossipSeeds:                 o.gossipSeeds,
 		gossipTimeout:               o.gossipTimeout,
 		clientConnectionTimeout:     o.clientConnectionTimeout,
+		sendQueueSize:               o.sendQueueSize,
+		sendQueueOverflowPolicy:     o.sendQueueOverflowPolicy,
+		sendQueueTimeout:            o.sendQueueTimeout,
+		reconnectionStrategy:        o.reconnectionStrategy,
+		retryPolicy:                 o.retryPolicy,
+		retryPolicies:               copyRetryPolicies(o.retryPolicies),
+		credentialsProvider:         o.credentialsProvider,
 	}
 }
 
@@ -94,6 +117,28 @@ func (csb *ConnectionSettingsBuilder) LimitOperationsQueueTo(limit int) *Connect
 	return csb
 }
 
+func (csb *ConnectionSettingsBuilder) LimitSendQueueTo(limit int) *ConnectionSettingsBuilder {
+	csb.sendQueueSize = limit
+	return csb
+}
+
+// The connection stops processing messages while it waits, so the timeout should be short. By default a package that
+// doesn't fit in the send queue fails its operation right away.
+func (csb *ConnectionSettingsBuilder) BlockWhenSendQueueIsFull(timeout time.Duration) *ConnectionSettingsBuilder {
+	if timeout <= 0 {
+		panic("timeout should be positive")
+	}
+	csb.sendQueueOverflowPolicy = SendQueueOverflowPolicy_Block
+	csb.sendQueueTimeout = timeout
+	return csb
+}
+
+func (csb *ConnectionSettingsBuilder) FailWhenSendQueueIsFull() *ConnectionSettingsBuilder {
+	csb.sendQueueOverflowPolicy = SendQueueOverflowPolicy_Fail
+	csb.sendQueueTimeout = 0
+	return csb
+}
+
 func (csb *ConnectionSettingsBuilder) LimitConcurrentOperationsTo(limit int) *ConnectionSettingsBuilder {
 	csb.maxConcurrentItem = limit
 	return csb
@@ -134,11 +179,32 @@ func (csb *ConnectionSettingsBuilder) PerformOnAnyNode() *ConnectionSettingsBuil
 	return csb
 }
 
+// Used for the operations without a policy of their own. Replaces the max retries limit.
+func (csb *ConnectionSettingsBuilder) SetRetryPolicy(policy RetryPolicy) *ConnectionSettingsBuilder {
+	csb.retryPolicy = policy
+	return csb
+}
+
+// Used for the operations sent with the given request command, for example Command_WriteEvents for appends.
+func (csb *ConnectionSettingsBuilder) SetRetryPolicyFor(command Command, policy RetryPolicy) *ConnectionSettingsBuilder {
+	if csb.retryPolicies == nil {
+		csb.retryPolicies = map[Command]RetryPolicy{}
+	}
+	csb.retryPolicies[command] = policy
+	return csb
+}
+
 func (csb *ConnectionSettingsBuilder) SetReconnectionDelayTo(delay time.Duration) *ConnectionSettingsBuilder {
 	csb.reconnectionDelay = delay
 	return csb
 }
 
+// Replaces the reconnection delay. MaxReconnections still limits the number of attempts.
+func (csb *ConnectionSettingsBuilder) SetReconnectionStrategy(strategy ReconnectionStrategy) *ConnectionSettingsBuilder {
+	csb.reconnectionStrategy = strategy
+	return csb
+}
+
 func (csb *ConnectionSettingsBuilder) SetOperationTimeoutTo(delay time.Duration) *ConnectionSettingsBuilder {
 	csb.operationTimeout = delay
 	return csb
@@ -154,6 +220,13 @@ func (csb *ConnectionSettingsBuilder) SetDefaultUserCredentials(userCredentials
 	return csb
 }
 
+// The provided credentials authenticate the connection and the operations started without credentials. The
+// connection is authenticated again when they change.
+func (csb *ConnectionSettingsBuilder) SetCredentialsProvider(provider CredentialsProvider) *ConnectionSettingsBuilder {
+	csb.credentialsProvider = provider
+	return csb
+}
+
 func (csb *ConnectionSettingsBuilder) UseSslConnection(targetHost string, validateServer bool) *ConnectionSettingsBuilder {
 	csb.useSslConnection = true
 	csb.targetHost = targetHost
@@ -161,6 +234,39 @@ func (csb *ConnectionSettingsBuilder) UseSslConnection(targetHost string, valida
 	return csb
 }
 
+// Used as the base of the TLS configuration when using a SSL connection. ServerName defaults to the target host.
+func (csb *ConnectionSettingsBuilder) SetTlsConfig(config *tls.Config) *ConnectionSettingsBuilder {
+	csb.tlsConfig = config
+	return csb
+}
+
+// Trust the certificate authorities from a PEM file instead of the system ones. The file is read right away and an
+// error is returned when it doesn't exist or contains no certificate. An empty path is ignored.
+func (csb *ConnectionSettingsBuilder) UseRootCertificateAuthority(caFile string) (*ConnectionSettingsBuilder, error) {
+	config, err := NewTlsConfig(caFile, "", "")
+	if err != nil {
+		return csb, err
+	}
+	if config.RootCAs != nil {
+		csb.tlsRootCAs = config.RootCAs
+	}
+	return csb, nil
+}
+
+// Present a client certificate loaded from PEM files to the server. The files are read right away and an error is
+// returned when they can't be loaded. Empty paths are ignored.
+func (csb *ConnectionSettingsBuilder) UseClientCertificate(
+	certFile string,
+	keyFile string,
+) (*ConnectionSettingsBuilder, error) {
+	config, err := NewTlsConfig("", certFile, keyFile)
+	if err != nil {
+		return csb, err
+	}
+	csb.tlsCertificates = append(csb.tlsCertificates, config.Certificates...)
+	return csb, nil
+}
+
 func (csb *ConnectionSettingsBuilder) FailOnNoServerResponse() *ConnectionSettingsBuilder {
 	csb.failOnNoServerResponse = true
 	return csb
@@ -216,6 +322,18 @@ func (csb *ConnectionSettingsBuilder) SetGossipSeeds(gossipSeeds []*GossipSeed)
 }
 
 func (csb *ConnectionSettingsBuilder) Build() *ConnectionSettings {
+	tlsConfig := csb.tlsConfig
+	if csb.tlsRootCAs != nil || len(csb.tlsCertificates) > 0 {
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		} else {
+			tlsConfig = tlsConfig.Clone()
+		}
+		if csb.tlsRootCAs != nil {
+			tlsConfig.RootCAs = csb.tlsRootCAs
+		}
+		tlsConfig.Certificates = append(tlsConfig.Certificates, csb.tlsCertificates...)
+	}
 	return newConnectionSettings(
 		csb.verboseLogging,
 		csb.maxQueueSize,
@@ -230,6 +348,7 @@ func (csb *ConnectionSettingsBuilder) Build() *ConnectionSettings {
 		csb.useSslConnection,
 		csb.targetHost,
 		csb.validateServer,
+		tlsConfig,
 		csb.failOnNoServerResponse,
 		csb.heartbeatInterval,
 		csb.heartbeatTimeout,
@@ -239,5 +358,23 @@ func (csb *ConnectionSettingsBuilder) Build() *ConnectionSettings {
 		csb.gossipSeeds,
 		csb.gossipTimeout,
 		csb.clientConnectionTimeout,
+		csb.sendQueueSize,
+		csb.sendQueueOverflowPolicy,
+		csb.sendQueueTimeout,
+		csb.reconnectionStrategy,
+		csb.retryPolicy,
+		copyRetryPolicies(csb.retryPolicies),
+		csb.credentialsProvider,
 	)
 }
+
+func copyRetryPolicies(policies map[Command]RetryPolicy) map[Command]RetryPolicy {
+	if policies == nil {
+		return nil
+	}
+	result := make(map[Command]RetryPolicy, len(policies))
+	for command, policy := range policies {
+		result[command] = policy
+	}
+	return result
+}
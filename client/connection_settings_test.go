@@ -0,0 +1,46 @@
+package client_test
+
+import (
+	"github.com/jdextraze/go-gesclient/client"
+	"testing"
+	"time"
+)
+
+func TestConnectionSettings_Heartbeat(t *testing.T) {
+	settings := client.CreateConnectionSettings().Build()
+	if settings.HeartbeatInterval() != 750*time.Millisecond || settings.HeartbeatTimeout() != 1500*time.Millisecond {
+		t.Errorf("Default heartbeat doesn't match: %v %v", settings.HeartbeatInterval(), settings.HeartbeatTimeout())
+	}
+
+	settings = client.CreateConnectionSettings().
+		SetHeartbeatInterval(100 * time.Millisecond).
+		SetHeartbeatTimeout(3 * time.Second).
+		Build()
+	if settings.HeartbeatInterval() != 100*time.Millisecond {
+		t.Errorf("HeartbeatInterval doesn't match: %v", settings.HeartbeatInterval())
+	}
+	if settings.HeartbeatTimeout() != 3*time.Second {
+		t.Errorf("HeartbeatTimeout doesn't match: %v", settings.HeartbeatTimeout())
+	}
+}
+
+func TestConnectionSettings_SendQueue(t *testing.T) {
+	settings := client.CreateConnectionSettings().Build()
+	if settings.SendQueueOverflowPolicy() != client.SendQueueOverflowPolicy_Fail {
+		t.Errorf("Default send queue overflow policy doesn't match: %s", settings.SendQueueOverflowPolicy())
+	}
+
+	settings = client.CreateConnectionSettings().BlockWhenSendQueueIsFull(100 * time.Millisecond).Build()
+	if settings.SendQueueOverflowPolicy() != client.SendQueueOverflowPolicy_Block ||
+		settings.SendQueueTimeout() != 100*time.Millisecond {
+		t.Errorf("Send queue settings don't match: %s %v", settings.SendQueueOverflowPolicy(),
+			settings.SendQueueTimeout())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("BlockWhenSendQueueIsFull should panic when timeout is not positive")
+		}
+	}()
+	client.CreateConnectionSettings().BlockWhenSendQueueIsFull(0)
+}
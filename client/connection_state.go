@@ -0,0 +1,74 @@
+package client
+
+import (
+	"github.com/satori/go.uuid"
+	"net"
+	"time"
+)
+
+type ConnectionStatus int
+
+const (
+	ConnectionStatus_Init ConnectionStatus = iota
+	ConnectionStatus_Connecting
+	ConnectionStatus_Connected
+	ConnectionStatus_Closed
+)
+
+var connectionStatusValues = []string{
+	"Init",
+	"Connecting",
+	"Connected",
+	"Closed",
+}
+
+func (s ConnectionStatus) String() string {
+	return connectionStatusValues[s]
+}
+
+type ConnectingPhase int
+
+const (
+	ConnectingPhase_Invalid ConnectingPhase = iota
+	ConnectingPhase_Reconnecting
+	ConnectingPhase_EndpointDiscovery
+	ConnectingPhase_ConnectionEstablishing
+	ConnectingPhase_Authentication
+	ConnectingPhase_Connected
+)
+
+var connectingPhaseValues = []string{
+	"Invalid",
+	"Reconnecting",
+	"EndpointDiscovery",
+	"ConnectionEstablishing",
+	"Authentication",
+	"Connected",
+}
+
+func (p ConnectingPhase) String() string {
+	return connectingPhaseValues[p]
+}
+
+// Snapshot of a connection state, as returned by Connection.State()
+type ConnectionState struct {
+	Status              ConnectionStatus
+	ConnectingPhase     ConnectingPhase
+	RemoteEndpoint      net.Addr
+	LocalEndpoint       net.Addr
+	ConnectionId        uuid.UUID
+	ReconnectionAttempt int
+	// Time elapsed since a package was last received or a heartbeat request was last sent, 0 without a TCP connection
+	SinceLastHeartbeat        time.Duration
+	WaitingOperations         int
+	ActiveOperations          int
+	RetryPendingOperations    int
+	WaitingSubscriptions      int
+	ActiveSubscriptions       int
+	RetryPendingSubscriptions int
+	SendQueueLength           int
+}
+
+func (s *ConnectionState) IsConnected() bool {
+	return s.Status == ConnectionStatus_Connected
+}
@@ -7,6 +7,7 @@ const (
 	DefaultMaxConcurrentItems  int = 5000
 	DefaultMaxOperationRetries int = 10
 	DefaultMaxReconnections    int = 10
+	DefaultSendQueueSize       int = 65536
 
 	DefaultRequireMaster bool = true
 
@@ -16,6 +17,7 @@ const (
 
 	TimerPeriod                           time.Duration = 200 * time.Millisecond
 	MaxReadSize                           int           = 4096
+	MaxPackageSize                        int           = 64 * 1024 * 1024
 	DefaultMaxClusterDiscoverAttempts     int           = 10
 	DefaultClusterManagerExternalHttpPort int           = 30778
 
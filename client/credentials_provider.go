@@ -0,0 +1,103 @@
+package client
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Supplies the credentials used to authenticate the connection and the operations started without credentials.
+// Credentials() is called when connecting, for every operation and periodically to detect rotations, so
+// implementations fetching them remotely should cache them (see NewCachedCredentialsProvider).
+type CredentialsProvider interface {
+	Credentials() (*UserCredentials, error)
+}
+
+type CredentialsProviderFunc func() (*UserCredentials, error)
+
+func (f CredentialsProviderFunc) Credentials() (*UserCredentials, error) {
+	return f()
+}
+
+func NewStaticCredentialsProvider(credentials *UserCredentials) CredentialsProvider {
+	if credentials == nil {
+		panic("credentials is nil")
+	}
+	return CredentialsProviderFunc(func() (*UserCredentials, error) {
+		return credentials, nil
+	})
+}
+
+type cachedCredentialsProvider struct {
+	fetch       func() (*UserCredentials, error)
+	ttl         time.Duration
+	lock        sync.Mutex
+	credentials *UserCredentials
+	fetchedAt   time.Time
+}
+
+// Calls fetch at most once per ttl. When a refresh fails, the previous credentials are kept until it succeeds.
+func NewCachedCredentialsProvider(fetch func() (*UserCredentials, error), ttl time.Duration) CredentialsProvider {
+	if fetch == nil {
+		panic("fetch is nil")
+	}
+	return &cachedCredentialsProvider{fetch: fetch, ttl: ttl}
+}
+
+func (p *cachedCredentialsProvider) Credentials() (*UserCredentials, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.credentials != nil && time.Since(p.fetchedAt) < p.ttl {
+		return p.credentials, nil
+	}
+	credentials, err := p.fetch()
+	if err != nil {
+		if p.credentials != nil {
+			return p.credentials, nil
+		}
+		return nil, err
+	}
+	p.credentials = credentials
+	p.fetchedAt = time.Now()
+	return credentials, nil
+}
+
+type fileCredentialsProvider struct {
+	path        string
+	lock        sync.Mutex
+	credentials *UserCredentials
+	modTime     time.Time
+	size        int64
+}
+
+// Reads "username:password" from a file, reloading it whenever it changes.
+func NewFileCredentialsProvider(path string) CredentialsProvider {
+	return &fileCredentialsProvider{path: path}
+}
+
+func (p *fileCredentialsProvider) Credentials() (*UserCredentials, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return nil, err
+	}
+	if p.credentials != nil && info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		return p.credentials, nil
+	}
+	data, err := ioutil.ReadFile(p.path)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.SplitN(strings.TrimSpace(string(data)), ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("%s should contain username:password", p.path)
+	}
+	p.credentials = NewUserCredentials(parts[0], parts[1])
+	p.modTime = info.ModTime()
+	p.size = info.Size()
+	return p.credentials, nil
+}
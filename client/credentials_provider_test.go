@@ -0,0 +1,80 @@
+package client_test
+
+import (
+	"errors"
+	"github.com/jdextraze/go-gesclient/client"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileCredentialsProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "credentials")
+	provider := client.NewFileCredentialsProvider(path)
+
+	if _, err := provider.Credentials(); err == nil {
+		t.Error("Credentials should fail when the file doesn't exist")
+	}
+
+	ioutil.WriteFile(path, []byte("admin:changeit\n"), 0600)
+	credentials, err := provider.Credentials()
+	if err != nil {
+		t.Fatalf("Credentials failed: %v", err)
+	}
+	if !credentials.Equal(client.NewUserCredentials("admin", "changeit")) {
+		t.Errorf("Credentials doesn't match: %s", credentials.Username())
+	}
+
+	ioutil.WriteFile(path, []byte("rotated:secret:with:colons"), 0600)
+	credentials, err = provider.Credentials()
+	if err != nil {
+		t.Fatalf("Credentials failed: %v", err)
+	}
+	if !credentials.Equal(client.NewUserCredentials("rotated", "secret:with:colons")) {
+		t.Errorf("Rotated credentials doesn't match: %s", credentials.Username())
+	}
+
+	ioutil.WriteFile(path, []byte("invalid"), 0600)
+	if _, err := provider.Credentials(); err == nil {
+		t.Error("Credentials should fail with an invalid file")
+	}
+}
+
+func TestCachedCredentialsProvider(t *testing.T) {
+	calls := 0
+	var fetchErr error
+	provider := client.NewCachedCredentialsProvider(func() (*client.UserCredentials, error) {
+		calls++
+		return client.NewUserCredentials("user", "pass"), fetchErr
+	}, 20*time.Millisecond)
+
+	provider.Credentials()
+	provider.Credentials()
+	if calls != 1 {
+		t.Errorf("Calls doesn't match: %d != 1", calls)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	fetchErr = errors.New("unavailable")
+	credentials, err := provider.Credentials()
+	if err != nil || credentials == nil || calls != 2 {
+		t.Errorf("Previous credentials should be kept when refresh fails: %v %v %d", credentials, err, calls)
+	}
+}
+
+func TestUserCredentials_Equal(t *testing.T) {
+	var none *client.UserCredentials
+	if !none.Equal(nil) || none.Equal(client.NewUserCredentials("a", "b")) {
+		t.Error("nil credentials Equal doesn't match")
+	}
+	if client.NewUserCredentials("a", "b").Equal(client.NewUserCredentials("a", "c")) {
+		t.Error("Credentials with different passwords should not be equal")
+	}
+}
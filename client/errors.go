@@ -13,6 +13,8 @@ var (
 	AccessDenied         = errors.New("Access denied")
 	AuthenticationError  = errors.New("Authentication error")
 	BadRequest           = errors.New("Bad request")
+	NotFound             = errors.New("Not found")
+	Conflict             = errors.New("Conflict")
 )
 
 type ServerError struct {
@@ -41,3 +43,40 @@ func NewNotModified(stream string) error {
 func (e *NotModified) Error() string {
 	return fmt.Sprintf("Stream not modified: %s", e.stream)
 }
+
+type PackageTooLarge struct {
+	size    int
+	maxSize int
+}
+
+func NewPackageTooLarge(size int, maxSize int) error {
+	return &PackageTooLarge{size, maxSize}
+}
+
+func (e *PackageTooLarge) Error() string {
+	return fmt.Sprintf("Package size %d exceeds the maximum package size of %d", e.size, e.maxSize)
+}
+
+type QueueFull struct {
+	queueSize int
+}
+
+func NewQueueFull(queueSize int) error {
+	return &QueueFull{queueSize}
+}
+
+func (e *QueueFull) Error() string {
+	return fmt.Sprintf("Send queue is full (%d packages)", e.queueSize)
+}
+
+type InvalidExpectedVersion struct {
+	expectedVersion int
+}
+
+func NewInvalidExpectedVersion(expectedVersion int) error {
+	return &InvalidExpectedVersion{expectedVersion}
+}
+
+func (e *InvalidExpectedVersion) Error() string {
+	return fmt.Sprintf("Invalid expected version: %d", e.expectedVersion)
+}
@@ -1,6 +1,7 @@
 package client_test
 
 import (
+	"errors"
 	"github.com/jdextraze/go-gesclient/client"
 	"testing"
 )
@@ -59,3 +60,48 @@ func TestNotModified_Error(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestInvalidExpectedVersion_Error(t *testing.T) {
+	err := client.NewInvalidExpectedVersion(-3)
+	if err.Error() != "Invalid expected version: -3" {
+		t.FailNow()
+	}
+}
+
+func TestHttpError_Error(t *testing.T) {
+	err := client.NewHttpError(404, "GET", "http://localhost:2113/projection/test", "")
+	if err.Error() != "Server returned 404 (Not Found) for GET on http://localhost:2113/projection/test" {
+		t.FailNow()
+	}
+
+	err = client.NewHttpError(400, "PUT", "http://localhost:2113/projection/test/query", "invalid query")
+	if err.Error() != "Server returned 400 (Bad Request) for PUT on http://localhost:2113/projection/test/query: "+
+		"invalid query" {
+		t.FailNow()
+	}
+}
+
+func TestHttpError_Is(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		target     error
+	}{
+		{401, client.AccessDenied},
+		{403, client.AccessDenied},
+		{404, client.NotFound},
+		{409, client.Conflict},
+		{400, client.BadRequest},
+	}
+	for _, tt := range tests {
+		err := client.NewHttpError(tt.statusCode, "GET", "/", "")
+		if !errors.Is(err, tt.target) {
+			t.Errorf("%d should match %v", tt.statusCode, tt.target)
+		}
+		if errors.Is(err, client.WrongExpectedVersion) {
+			t.Errorf("%d should not match %v", tt.statusCode, client.WrongExpectedVersion)
+		}
+	}
+	if err := client.NewHttpError(500, "GET", "/", ""); errors.Is(err, client.NotFound) {
+		t.Error("500 should not match a sentinel error")
+	}
+}
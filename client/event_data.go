@@ -35,6 +35,9 @@ func (e *EventData) Data() []byte { return e.data }
 
 func (e *EventData) Metadata() []byte { return e.metadata }
 
+// Approximate size of the event once serialized: id, type, data and metadata
+func (e *EventData) Size() int { return len(e.eventId) + len(e.typ) + len(e.data) + len(e.metadata) }
+
 func (e *EventData) ToNewEvent() *messages.NewEvent {
 	var (
 		dataContentType     int32
@@ -0,0 +1,12 @@
+package client
+
+import (
+	"fmt"
+	"github.com/satori/go.uuid"
+)
+
+// Derives a name based (v5) event id from a namespace and a business key, for example a command id, and the index of
+// the event produced for that key. Writing again events with the same ids is detected by the server as a duplicate.
+func NewDeterministicEventId(namespace uuid.UUID, key string, index int) uuid.UUID {
+	return uuid.NewV5(namespace, fmt.Sprintf("%s/%d", key, index))
+}
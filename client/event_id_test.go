@@ -0,0 +1,24 @@
+package client_test
+
+import (
+	"github.com/jdextraze/go-gesclient/client"
+	"github.com/satori/go.uuid"
+	"testing"
+)
+
+func TestNewDeterministicEventId(t *testing.T) {
+	namespace := uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+	id := client.NewDeterministicEventId(namespace, "command", 0)
+	if id.Version() != uuid.V5 {
+		t.Errorf("Version doesn't match: %d != %d", id.Version(), uuid.V5)
+	}
+	if !uuid.Equal(id, client.NewDeterministicEventId(namespace, "command", 0)) {
+		t.Error("Ids should be equal for the same namespace, key and index")
+	}
+	if uuid.Equal(id, client.NewDeterministicEventId(namespace, "command", 1)) {
+		t.Error("Ids should differ for another index")
+	}
+	if uuid.Equal(id, client.NewDeterministicEventId(uuid.Must(uuid.NewV4()), "command", 0)) {
+		t.Error("Ids should differ for another namespace")
+	}
+}
@@ -1,7 +1,38 @@
 package client
 
+import "fmt"
+
 const (
-	ExpectedVersion_Any         = -2
-	ExpectedVersion_NoStream    = -1
-	ExpectedVersion_EmptyStream = -1
+	ExpectedVersion_Any          = -2
+	ExpectedVersion_NoStream     = -1
+	ExpectedVersion_EmptyStream  = -1
+	ExpectedVersion_StreamExists = -4
 )
+
+// An expected version is either one of the ExpectedVersion_ constants or the number of the last event of the stream
+type ExpectedVersion int
+
+func NewExpectedVersion(version int) (ExpectedVersion, error) {
+	v := ExpectedVersion(version)
+	return v, v.Validate()
+}
+
+func (v ExpectedVersion) Validate() error {
+	if v < ExpectedVersion_Any && v != ExpectedVersion_StreamExists {
+		return NewInvalidExpectedVersion(int(v))
+	}
+	return nil
+}
+
+func (v ExpectedVersion) String() string {
+	switch v {
+	case ExpectedVersion_Any:
+		return "Any"
+	case ExpectedVersion_NoStream:
+		return "NoStream"
+	case ExpectedVersion_StreamExists:
+		return "StreamExists"
+	default:
+		return fmt.Sprint(int(v))
+	}
+}
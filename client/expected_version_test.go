@@ -0,0 +1,30 @@
+package client_test
+
+import (
+	"github.com/jdextraze/go-gesclient/client"
+	"testing"
+)
+
+func TestNewExpectedVersion(t *testing.T) {
+	for _, version := range []int{client.ExpectedVersion_Any, client.ExpectedVersion_NoStream,
+		client.ExpectedVersion_StreamExists, 0, 12} {
+		if _, err := client.NewExpectedVersion(version); err != nil {
+			t.Errorf("NewExpectedVersion(%d) failed: %v", version, err)
+		}
+	}
+	for _, version := range []int{-3, -5, -100} {
+		if _, err := client.NewExpectedVersion(version); err == nil {
+			t.Errorf("NewExpectedVersion(%d) should fail", version)
+		}
+	}
+}
+
+func TestExpectedVersion_String(t *testing.T) {
+	var v client.ExpectedVersion = client.ExpectedVersion_StreamExists
+	if v.String() != "StreamExists" {
+		t.Errorf("String doesn't match: %s != StreamExists", v)
+	}
+	if client.ExpectedVersion(12).String() != "12" {
+		t.Errorf("String doesn't match: %s != 12", client.ExpectedVersion(12))
+	}
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Returned by the HTTP admin clients when the server answers with an unexpected status code. errors.Is matches it
+// against AccessDenied, NotFound, Conflict and BadRequest according to the status code.
+type HttpError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	Body       string
+}
+
+func NewHttpError(statusCode int, method string, url string, body string) error {
+	return &HttpError{
+		StatusCode: statusCode,
+		Method:     method,
+		URL:        url,
+		Body:       body,
+	}
+}
+
+func (e *HttpError) Error() string {
+	msg := fmt.Sprintf("Server returned %d (%s) for %s on %s", e.StatusCode, http.StatusText(e.StatusCode), e.Method,
+		e.URL)
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+	return msg
+}
+
+func (e *HttpError) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return target == AccessDenied
+	case http.StatusNotFound:
+		return target == NotFound
+	case http.StatusConflict:
+		return target == Conflict
+	case http.StatusBadRequest:
+		return target == BadRequest
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package client
+
+import "fmt"
+
+type IdempotentWriteResult struct {
+	*WriteResult
+	duplicate bool
+}
+
+func NewIdempotentWriteResult(writeResult *WriteResult, duplicate bool) *IdempotentWriteResult {
+	return &IdempotentWriteResult{
+		WriteResult: writeResult,
+		duplicate:   duplicate,
+	}
+}
+
+// True when the events were already written with the same ids
+func (r *IdempotentWriteResult) IsDuplicate() bool { return r.duplicate }
+
+func (r *IdempotentWriteResult) String() string {
+	return fmt.Sprintf("&{nextExpectedVersion:%d logPosition:%+v duplicate:%t}", r.nextExpectedVersion,
+		r.logPosition, r.duplicate)
+}
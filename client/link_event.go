@@ -0,0 +1,30 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/jdextraze/go-gesclient/common"
+	"github.com/satori/go.uuid"
+)
+
+var linkEventNamespace = uuid.FromStringOrNil("b493e8d0-5687-41a6-a5db-a024449591f8")
+
+// Builds a $> event linking to evt, which reads resolve to evt when resolveLinkTos is set. Its body is
+// "<event number>@<stream>" and its metadata records the linked stream and event id like the links of the system
+// projections. The id of the link is derived from targetStream and the linked event, so writing it again to the same
+// stream is detected as a duplicate while links in other streams get their own id.
+func NewLinkEventData(targetStream string, evt *RecordedEvent) *EventData {
+	if targetStream == "" {
+		panic("targetStream must be present")
+	}
+	if evt == nil {
+		panic("evt is nil")
+	}
+	data := fmt.Sprintf("%d@%s", evt.EventNumber(), evt.EventStreamId())
+	metadata, _ := json.Marshal(map[string]string{
+		"$o":        evt.EventStreamId(),
+		"$causedBy": evt.EventId().String(),
+	})
+	id := NewDeterministicEventId(linkEventNamespace, targetStream+"/"+data, 0)
+	return NewEventData(id, common.SystemEventTypes_LinkTo, false, []byte(data), metadata)
+}
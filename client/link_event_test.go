@@ -0,0 +1,44 @@
+package client_test
+
+import (
+	"encoding/json"
+	"github.com/golang/protobuf/proto"
+	"github.com/jdextraze/go-gesclient/client"
+	"github.com/jdextraze/go-gesclient/messages"
+	"github.com/satori/go.uuid"
+	"testing"
+)
+
+func newRecordedEvent(stream string, eventNumber int32) *client.RecordedEvent {
+	return client.NewResolvedEvent(&messages.ResolvedIndexedEvent{
+		Event: &messages.EventRecord{
+			EventStreamId: proto.String(stream),
+			EventNumber:   proto.Int32(eventNumber),
+			EventId:       uuid.Must(uuid.NewV4()).Bytes(),
+			EventType:     proto.String("Deposited"),
+			Data:          []byte("{}"),
+		},
+	}).Event()
+}
+
+func TestNewLinkEventData(t *testing.T) {
+	evt := newRecordedEvent("account-1", 3)
+	link := client.NewLinkEventData("deposits", evt)
+	if link.Type() != "$>" || link.IsJson() || string(link.Data()) != "3@account-1" {
+		t.Errorf("Link doesn't match: %s", link)
+	}
+	metadata := map[string]string{}
+	if err := json.Unmarshal(link.Metadata(), &metadata); err != nil || metadata["$o"] != "account-1" ||
+		metadata["$causedBy"] != evt.EventId().String() {
+		t.Errorf("Metadata doesn't match: %s %v", link.Metadata(), err)
+	}
+	if !uuid.Equal(link.EventId(), client.NewLinkEventData("deposits", evt).EventId()) {
+		t.Error("Links to the same event should have the same id")
+	}
+	if uuid.Equal(link.EventId(), client.NewLinkEventData("deposits", newRecordedEvent("account-1", 4)).EventId()) {
+		t.Error("Links to different events should have different ids")
+	}
+	if uuid.Equal(link.EventId(), client.NewLinkEventData("large-deposits", evt).EventId()) {
+		t.Error("Links to the same event in different streams should have different ids")
+	}
+}
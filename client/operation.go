@@ -8,4 +8,6 @@ type Operation interface {
 	CreateNetworkPackage(correlationId uuid.UUID) (*Package, error)
 	InspectPackage(p *Package) (*InspectionResult, error)
 	Fail(err error) error
+	RequestCommand() Command
+	IsIdempotent() bool
 }
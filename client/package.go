@@ -89,7 +89,7 @@ func TcpPacketFromBytes(data []byte) (*Package, error) {
 		usernameLength := int(data[PackageAuthOffset])
 		usernameStartOffset := PackageAuthOffset + 1
 		usernameEndOffset := usernameStartOffset + usernameLength
-		if usernameEndOffset > dataLength {
+		if usernameEndOffset >= dataLength {
 			return nil, errors.New("Username length is too big, it does not fit into TcpPackage.")
 		}
 		username = string(data[usernameStartOffset:usernameEndOffset])
@@ -98,7 +98,7 @@ func TcpPacketFromBytes(data []byte) (*Package, error) {
 		if usernameEndOffset+1+passwordLength > dataLength {
 			return nil, errors.New("Password length is too big, it does not fit into TcpPackage.")
 		}
-		username = string(data[usernameEndOffset+1 : usernameEndOffset+1+passwordLength])
+		password = string(data[usernameEndOffset+1 : usernameEndOffset+1+passwordLength])
 
 		headerSize += 1 + usernameLength + 1 + passwordLength
 	}
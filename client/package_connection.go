@@ -1,9 +1,8 @@
 package client
 
 import (
-	"bytes"
+	"bufio"
 	"crypto/tls"
-	"encoding/binary"
 	"errors"
 	"github.com/jdextraze/go-gesclient/log"
 	"github.com/satori/go.uuid"
@@ -23,6 +22,7 @@ type PackageConnection struct {
 	ssl                   bool
 	targetHost            string
 	validateServer        bool
+	tlsConfig             *tls.Config
 	timeout               time.Duration
 	packageHandler        func(conn *PackageConnection, packet *Package)
 	errorHandler          func(conn *PackageConnection, err error)
@@ -30,9 +30,11 @@ type PackageConnection struct {
 	connectionClosed      func(conn *PackageConnection, err error)
 	conn                  net.Conn
 
-	leftOver  []byte
-	sendQueue chan *Package
-	isClosed  int32
+	sendQueue               chan *Package
+	sendQueueOverflowPolicy SendQueueOverflowPolicy
+	sendQueueTimeout        time.Duration
+	closed                  chan struct{}
+	isClosed                int32
 
 	localEndpoint net.Addr
 }
@@ -43,24 +45,32 @@ func NewPackageConnection(
 	ssl bool,
 	targetHost string,
 	validateServer bool,
+	tlsConfig *tls.Config,
 	timeout time.Duration,
+	sendQueueSize int,
+	sendQueueOverflowPolicy SendQueueOverflowPolicy,
+	sendQueueTimeout time.Duration,
 	packageHandler func(conn *PackageConnection, packet *Package),
 	errorHandler func(conn *PackageConnection, err error),
 	connectionEstablished func(conn *PackageConnection),
 	connectionClosed func(conn *PackageConnection, err error),
 ) *PackageConnection {
 	c := &PackageConnection{
-		ipEndpoint:            ipEndpoint,
-		connectionId:          connectionId,
-		ssl:                   ssl,
-		targetHost:            targetHost,
-		validateServer:        validateServer,
-		timeout:               timeout,
-		packageHandler:        packageHandler,
-		errorHandler:          errorHandler,
-		connectionEstablished: connectionEstablished,
-		connectionClosed:      connectionClosed,
-		sendQueue:             make(chan *Package, 65536), // TODO buffer size
+		ipEndpoint:              ipEndpoint,
+		connectionId:            connectionId,
+		ssl:                     ssl,
+		targetHost:              targetHost,
+		validateServer:          validateServer,
+		tlsConfig:               tlsConfig,
+		timeout:                 timeout,
+		packageHandler:          packageHandler,
+		errorHandler:            errorHandler,
+		connectionEstablished:   connectionEstablished,
+		connectionClosed:        connectionClosed,
+		sendQueue:               make(chan *Package, sendQueueSize),
+		sendQueueOverflowPolicy: sendQueueOverflowPolicy,
+		sendQueueTimeout:        sendQueueTimeout,
+		closed:                  make(chan struct{}),
 	}
 	c.connect()
 	return c
@@ -74,7 +84,7 @@ func (c *PackageConnection) connect() {
 
 	if c.ssl {
 		conn, err = tls.DialWithDialer(&net.Dialer{Timeout: c.timeout}, c.ipEndpoint.Network(), c.ipEndpoint.String(),
-			&tls.Config{ServerName: c.targetHost, InsecureSkipVerify: !c.validateServer})
+			c.newTlsConfig())
 	} else {
 		conn, err = net.DialTimeout(c.ipEndpoint.Network(), c.ipEndpoint.String(), c.timeout)
 	}
@@ -88,52 +98,104 @@ func (c *PackageConnection) connect() {
 		c.localEndpoint = conn.LocalAddr()
 		log.Debugf("Connection to %s succeeded.", c.ipEndpoint)
 		c.conn = conn
+		go c.sender()
 		if c.connectionEstablished != nil {
 			c.connectionEstablished(c)
 		}
 	}
+}
 
-	go c.sender()
+func (c *PackageConnection) newTlsConfig() *tls.Config {
+	var config *tls.Config
+	if c.tlsConfig != nil {
+		config = c.tlsConfig.Clone()
+	} else {
+		config = &tls.Config{}
+	}
+	if config.ServerName == "" {
+		config.ServerName = c.targetHost
+	}
+	if !c.validateServer {
+		config.InsecureSkipVerify = true
+	}
+	return config
 }
 
 func (c *PackageConnection) receiver() {
+	reader := newFrameReader(c.conn, MaxPackageSize)
+	defer reader.release()
+
 	var err error
 	for {
-		buff := make([]byte, 4096)
-		n, err := c.conn.Read(buff)
-		if err == nil {
-			c.onData(buff[:n])
-		} else if err == io.EOF {
+		var frame []byte
+		if frame, err = reader.next(); err != nil {
+			break
+		}
+		var p *Package
+		if p, err = TcpPacketFromBytes(frame); err != nil {
 			break
-		} else {
-			if isClosedConnError(err) {
-				break
-			}
-			log.Errorf("conn.Read: %v", err)
 		}
+		c.packageHandler(c, p)
+	}
+	if err == io.EOF || isClosedConnError(err) {
+		err = nil
+	} else {
+		log.Errorf("PackageConnection.receiver: %v", err)
 	}
 	c.closeInternal("Socket receive error", err)
 }
 
 func (c *PackageConnection) sender() {
+	writer := acquireFrameWriter(c.conn)
+	defer releaseFrameWriter(writer)
+
 	var err error
-	for p := range c.sendQueue {
-		if err = binary.Write(c.conn, binary.LittleEndian, p.Size()); err != nil {
-			log.Errorf("binary.Write failed: %v", err)
-			break
-		}
-		if _, err = c.conn.Write(p.Bytes()); err != nil {
-			log.Errorf("net.Conn.Write failed: %v", err)
-			break
+	for err == nil {
+		select {
+		case p := <-c.sendQueue:
+			if err = c.write(writer, p); err == nil {
+				err = c.writeQueued(writer)
+			}
+			if err == nil {
+				err = writer.Flush()
+			}
+			if err != nil {
+				log.Errorf("net.Conn.Write failed: %v", err)
+			}
+		case <-c.closed:
+			return
 		}
-		log.Debugf("Sent Command: %s | CorrelationId: %s", p.Command(), p.CorrelationId())
 	}
 	c.closeInternal("Socket send error.", err)
 }
 
+// writeQueued coalesces the packages already waiting in the send queue into the current write, so they are
+// flushed to the socket together.
+func (c *PackageConnection) writeQueued(writer *bufio.Writer) error {
+	for writer.Buffered() < sendBufferSize {
+		select {
+		case p := <-c.sendQueue:
+			if err := c.write(writer, p); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (c *PackageConnection) write(writer *bufio.Writer, p *Package) error {
+	if err := writeFrame(writer, p); err != nil {
+		return err
+	}
+	log.Debugf("Sent Command: %s | CorrelationId: %s", p.Command(), p.CorrelationId())
+	return nil
+}
+
 func (c *PackageConnection) closeInternal(reason string, socketError error) {
 	if atomic.CompareAndSwapInt32(&c.isClosed, 0, 1) {
-		close(c.sendQueue)
+		close(c.closed)
 		log.Debugf("PackageConnection.closeInternal: %s. %v", reason, c.conn.Close())
 		if c.connectionClosed != nil {
 			c.connectionClosed(c, socketError)
@@ -143,33 +205,6 @@ func (c *PackageConnection) closeInternal(reason string, socketError error) {
 
 const tcpPacketContentLengthSize = 4
 
-func (c *PackageConnection) onData(data []byte) {
-	if c.leftOver != nil && len(c.leftOver) > 0 {
-		data = append(c.leftOver, data...)
-		c.leftOver = nil
-	}
-
-	dataLength := int32(len(data))
-	if dataLength < tcpPacketContentLengthSize {
-		c.leftOver = data
-		return
-	}
-	var contentLength int32
-	var buf = bytes.NewBuffer(data)
-	binary.Read(buf, binary.LittleEndian, &contentLength)
-
-	packetSize := contentLength + tcpPacketContentLengthSize
-	if dataLength == packetSize {
-		p, _ := TcpPacketFromBytes(data[tcpPacketContentLengthSize:])
-		c.packageHandler(c, p)
-	} else if dataLength > packetSize {
-		c.onData(data[:packetSize])
-		c.onData(data[packetSize:])
-	} else {
-		c.leftOver = data
-	}
-}
-
 func (c *PackageConnection) RemoteEndpoint() net.Addr { return c.ipEndpoint }
 
 func (c *PackageConnection) LocalEndpoint() net.Addr { return c.localEndpoint }
@@ -192,10 +227,37 @@ func (c *PackageConnection) EnqueueSend(p *Package) error {
 	if c.IsClosed() {
 		return errors.New("Connection is closed")
 	}
-	c.sendQueue <- p
-	return nil
+	if size := int(p.Size()); size > MaxPackageSize {
+		return NewPackageTooLarge(size, MaxPackageSize)
+	}
+
+	select {
+	case c.sendQueue <- p:
+		return nil
+	default:
+	}
+
+	if c.sendQueueOverflowPolicy == SendQueueOverflowPolicy_Fail {
+		return NewQueueFull(cap(c.sendQueue))
+	}
+	var timeout <-chan time.Time
+	if c.sendQueueTimeout > 0 {
+		timer := time.NewTimer(c.sendQueueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case c.sendQueue <- p:
+		return nil
+	case <-timeout:
+		return NewQueueFull(cap(c.sendQueue))
+	case <-c.closed:
+		return errors.New("Connection is closed")
+	}
 }
 
+func (c *PackageConnection) SendQueueLength() int { return len(c.sendQueue) }
+
 func (c *PackageConnection) Close(reason string) error {
 	if c.conn == nil {
 		return errors.New("Failed connection")
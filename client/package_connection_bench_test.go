@@ -0,0 +1,78 @@
+package client_test
+
+import (
+	"github.com/jdextraze/go-gesclient/client"
+	"github.com/satori/go.uuid"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+)
+
+func benchmarkSend(dataSize int, b *testing.B) {
+	l, accepted := listen(b)
+	defer l.Close()
+
+	c := newTestPackageConnection(l.Addr(), func(conn *client.PackageConnection, p *client.Package) {}, nil)
+	server := <-accepted
+	defer server.Close()
+
+	p := client.NewTcpPackage(client.Command_WriteEvents, client.FlagsNone, uuid.Must(uuid.NewV4()),
+		make([]byte, dataSize), nil)
+	total := int64(b.N) * int64(4+p.Size())
+	done := make(chan error)
+	go func() {
+		_, err := io.CopyN(ioutil.Discard, server, total)
+		done <- err
+	}()
+
+	b.SetBytes(int64(4 + p.Size()))
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if err := c.EnqueueSend(p); err != nil {
+			b.Fatal(err)
+		}
+	}
+	if err := <-done; err != nil {
+		b.Fatal(err)
+	}
+}
+
+func BenchmarkPackageConnectionSend_100(b *testing.B)   { benchmarkSend(100, b) }
+func BenchmarkPackageConnectionSend_1000(b *testing.B)  { benchmarkSend(1000, b) }
+func BenchmarkPackageConnectionSend_10000(b *testing.B) { benchmarkSend(10000, b) }
+
+func benchmarkReceive(dataSize int, b *testing.B) {
+	l, accepted := listen(b)
+	defer l.Close()
+
+	received := make(chan struct{}, 1024)
+	c := newTestPackageConnection(l.Addr(), func(conn *client.PackageConnection, p *client.Package) {
+		received <- struct{}{}
+	}, nil)
+	if err := c.StartReceiving(); err != nil {
+		b.Fatal(err)
+	}
+	server := <-accepted
+	defer server.Close()
+
+	data := frame(client.NewTcpPackage(client.Command_WriteEventsCompleted, client.FlagsNone,
+		uuid.Must(uuid.NewV4()), make([]byte, dataSize), nil))
+	go func(conn net.Conn) {
+		for n := 0; n < b.N; n++ {
+			if _, err := conn.Write(data); err != nil {
+				return
+			}
+		}
+	}(server)
+
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		<-received
+	}
+}
+
+func BenchmarkPackageConnectionReceive_100(b *testing.B)   { benchmarkReceive(100, b) }
+func BenchmarkPackageConnectionReceive_1000(b *testing.B)  { benchmarkReceive(1000, b) }
+func BenchmarkPackageConnectionReceive_10000(b *testing.B) { benchmarkReceive(10000, b) }
@@ -0,0 +1,184 @@
+package client_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/jdextraze/go-gesclient/client"
+	"github.com/satori/go.uuid"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func listen(t testing.TB) (net.Listener, chan net.Conn) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen failed: %v", err)
+	}
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err == nil {
+			accepted <- conn
+		}
+	}()
+	return l, accepted
+}
+
+func frame(p *client.Package) []byte {
+	b := make([]byte, 4, 4+p.Size())
+	binary.LittleEndian.PutUint32(b, uint32(p.Size()))
+	return append(b, p.Bytes()...)
+}
+
+func newTestPackageConnection(
+	addr net.Addr,
+	packageHandler func(conn *client.PackageConnection, packet *client.Package),
+	connectionClosed func(conn *client.PackageConnection, err error),
+) *client.PackageConnection {
+	return client.NewPackageConnection(addr, uuid.Must(uuid.NewV4()), false, "", false, nil, time.Second,
+		client.DefaultSendQueueSize, client.SendQueueOverflowPolicy_Block, 0, packageHandler, nil, nil,
+		connectionClosed)
+}
+
+func TestPackageConnection_ReceiveSplitAndCoalescedFrames(t *testing.T) {
+	l, accepted := listen(t)
+	defer l.Close()
+
+	received := make(chan *client.Package, 3)
+	c := newTestPackageConnection(l.Addr(), func(conn *client.PackageConnection, p *client.Package) {
+		received <- p
+	}, nil)
+	if err := c.StartReceiving(); err != nil {
+		t.Fatalf("StartReceiving failed: %v", err)
+	}
+	server := <-accepted
+	defer server.Close()
+
+	packages := []*client.Package{
+		client.NewTcpPackage(client.Command_Ping, client.FlagsNone, uuid.Must(uuid.NewV4()), []byte("first"), nil),
+		client.NewTcpPackage(client.Command_Pong, client.FlagsNone, uuid.Must(uuid.NewV4()), nil, nil),
+		client.NewTcpPackage(client.Command_Ping, client.FlagsNone, uuid.Must(uuid.NewV4()),
+			bytes.Repeat([]byte{1}, 200*1024), nil),
+	}
+	var stream []byte
+	for _, p := range packages {
+		stream = append(stream, frame(p)...)
+	}
+	for i := 0; i < 60; i += 3 {
+		server.Write(stream[i : i+3])
+	}
+	server.Write(stream[60:])
+
+	for _, expected := range packages {
+		select {
+		case p := <-received:
+			if p.Command() != expected.Command() || !uuid.Equal(p.CorrelationId(), expected.CorrelationId()) ||
+				!bytes.Equal(p.Data(), expected.Data()) {
+				t.Errorf("Package doesn't match: %v != %v", p, expected)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Package not received: %v", expected)
+		}
+	}
+}
+
+func TestPackageConnection_ReceiveTooLargeFrame(t *testing.T) {
+	l, accepted := listen(t)
+	defer l.Close()
+
+	closed := make(chan error, 1)
+	c := newTestPackageConnection(l.Addr(), func(conn *client.PackageConnection, p *client.Package) {
+		t.Errorf("Package should not be handled: %v", p)
+	}, func(conn *client.PackageConnection, err error) {
+		closed <- err
+	})
+	if err := c.StartReceiving(); err != nil {
+		t.Fatalf("StartReceiving failed: %v", err)
+	}
+	server := <-accepted
+	defer server.Close()
+
+	header := make([]byte, 4)
+	binary.LittleEndian.PutUint32(header, uint32(client.MaxPackageSize+1))
+	server.Write(header)
+
+	select {
+	case err := <-closed:
+		if _, ok := err.(*client.PackageTooLarge); !ok {
+			t.Errorf("Unexpected close error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Connection was not closed")
+	}
+}
+
+func TestPackageConnection_EnqueueSend(t *testing.T) {
+	l, accepted := listen(t)
+	defer l.Close()
+
+	c := newTestPackageConnection(l.Addr(), func(conn *client.PackageConnection, p *client.Package) {}, nil)
+	server := <-accepted
+	defer server.Close()
+
+	packages := make([]*client.Package, 100)
+	var expected []byte
+	for i := range packages {
+		packages[i] = client.NewTcpPackage(client.Command_Ping, client.FlagsAuthenticated, uuid.Must(uuid.NewV4()),
+			[]byte{byte(i)}, client.NewUserCredentials("user", "pass"))
+		expected = append(expected, frame(packages[i])...)
+	}
+	for _, p := range packages {
+		if err := c.EnqueueSend(p); err != nil {
+			t.Fatalf("EnqueueSend failed: %v", err)
+		}
+	}
+
+	actual := make([]byte, len(expected))
+	server.SetReadDeadline(time.Now().Add(time.Second))
+	if _, err := io.ReadFull(server, actual); err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if !bytes.Equal(actual, expected) {
+		t.Error("Sent bytes doesn't match")
+	}
+
+	tooLarge := client.NewTcpPackage(client.Command_Ping, client.FlagsNone, uuid.Must(uuid.NewV4()),
+		make([]byte, client.MaxPackageSize), nil)
+	if _, ok := c.EnqueueSend(tooLarge).(*client.PackageTooLarge); !ok {
+		t.Error("EnqueueSend should fail with PackageTooLarge")
+	}
+}
+
+func TestPackageConnection_EnqueueSendWhenQueueIsFull(t *testing.T) {
+	for _, policy := range []client.SendQueueOverflowPolicy{
+		client.SendQueueOverflowPolicy_Fail,
+		client.SendQueueOverflowPolicy_Block,
+	} {
+		testEnqueueSendWhenQueueIsFull(t, policy)
+	}
+}
+
+func testEnqueueSendWhenQueueIsFull(t *testing.T, policy client.SendQueueOverflowPolicy) {
+	l, accepted := listen(t)
+	defer l.Close()
+
+	c := client.NewPackageConnection(l.Addr(), uuid.Must(uuid.NewV4()), false, "", false, nil, time.Second, 1,
+		policy, 10*time.Millisecond, func(conn *client.PackageConnection, p *client.Package) {}, nil, nil, nil)
+	defer c.Close("Test completed")
+	server := <-accepted
+	defer server.Close()
+
+	var err error
+	for i := 0; i < 100 && err == nil; i++ {
+		err = c.EnqueueSend(client.NewTcpPackage(client.Command_Ping, client.FlagsNone, uuid.Must(uuid.NewV4()),
+			make([]byte, 1024*1024), nil))
+	}
+	if _, ok := err.(*client.QueueFull); !ok {
+		t.Errorf("EnqueueSend with %s policy should fail with QueueFull: %v", policy, err)
+	}
+	if c.SendQueueLength() != 1 {
+		t.Errorf("SendQueueLength with %s policy doesn't match: %d != 1", policy, c.SendQueueLength())
+	}
+}
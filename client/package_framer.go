@@ -0,0 +1,135 @@
+package client
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"github.com/jdextraze/go-gesclient/guid"
+	"io"
+	"sync"
+)
+
+const (
+	receiveBufferSize = 64 * 1024
+	sendBufferSize    = 64 * 1024
+)
+
+var receiveBufferPool = sync.Pool{
+	New: func() interface{} { return make([]byte, receiveBufferSize) },
+}
+
+var sendWriterPool = sync.Pool{
+	New: func() interface{} { return bufio.NewWriterSize(nil, sendBufferSize) },
+}
+
+// frameReader splits a length-prefixed byte stream into package frames. Data is read into a pooled buffer
+// that is reused between reads; only frames that do not fit in that buffer are read directly into their
+// own slice.
+type frameReader struct {
+	r       io.Reader
+	buf     []byte
+	start   int
+	end     int
+	maxSize int
+	err     error
+}
+
+func newFrameReader(r io.Reader, maxSize int) *frameReader {
+	return &frameReader{
+		r:       r,
+		buf:     receiveBufferPool.Get().([]byte),
+		maxSize: maxSize,
+	}
+}
+
+func (f *frameReader) next() ([]byte, error) {
+	for {
+		available := f.end - f.start
+		if available >= tcpPacketContentLengthSize {
+			contentLength := int(binary.LittleEndian.Uint32(f.buf[f.start:]))
+			if contentLength < PackageMandatorySize {
+				return nil, fmt.Errorf("Invalid package size %d", contentLength)
+			}
+			if contentLength > f.maxSize {
+				return nil, NewPackageTooLarge(contentLength, f.maxSize)
+			}
+			frameSize := tcpPacketContentLengthSize + contentLength
+			if available >= frameSize {
+				frame := make([]byte, contentLength)
+				copy(frame, f.buf[f.start+tcpPacketContentLengthSize:f.start+frameSize])
+				f.consume(frameSize)
+				return frame, nil
+			}
+			if frameSize > len(f.buf) {
+				frame := make([]byte, contentLength)
+				n := copy(frame, f.buf[f.start+tcpPacketContentLengthSize:f.end])
+				f.consume(available)
+				if _, err := io.ReadFull(f.r, frame[n:]); err != nil {
+					return nil, err
+				}
+				return frame, nil
+			}
+		}
+		if f.err != nil {
+			return nil, f.err
+		}
+		f.fill()
+	}
+}
+
+func (f *frameReader) consume(n int) {
+	f.start += n
+	if f.start == f.end {
+		f.start = 0
+		f.end = 0
+	}
+}
+
+func (f *frameReader) fill() {
+	if f.start > 0 {
+		copy(f.buf, f.buf[f.start:f.end])
+		f.end -= f.start
+		f.start = 0
+	}
+	n, err := f.r.Read(f.buf[f.end:])
+	f.end += n
+	f.err = err
+}
+
+func (f *frameReader) release() {
+	if f.buf != nil {
+		receiveBufferPool.Put(f.buf)
+		f.buf = nil
+	}
+}
+
+func acquireFrameWriter(w io.Writer) *bufio.Writer {
+	bw := sendWriterPool.Get().(*bufio.Writer)
+	bw.Reset(w)
+	return bw
+}
+
+func releaseFrameWriter(bw *bufio.Writer) {
+	bw.Reset(nil)
+	sendWriterPool.Put(bw)
+}
+
+// writeFrame writes the length prefix and the package directly into w, without building an intermediate slice.
+func writeFrame(w *bufio.Writer, p *Package) error {
+	var header [tcpPacketContentLengthSize + PackageMandatorySize]byte
+	binary.LittleEndian.PutUint32(header[:], uint32(p.Size()))
+	header[tcpPacketContentLengthSize+PackageCommandOffset] = byte(p.command)
+	header[tcpPacketContentLengthSize+PackageFlagsOffset] = byte(p.flags)
+	copy(header[tcpPacketContentLengthSize+PackageCorrelationOffset:], guid.ToBytes(p.correlationId))
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	if p.flags&FlagsAuthenticated != 0 {
+		w.WriteByte(byte(len(p.username)))
+		w.WriteString(p.username)
+		w.WriteByte(byte(len(p.password)))
+		w.WriteString(p.password)
+	}
+	_, err := w.Write(p.data)
+	return err
+}
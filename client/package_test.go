@@ -139,4 +139,14 @@ func TestTcpPacketFromBytes(t *testing.T) {
 	if err == nil || err.Error() != "Password length is too big, it does not fit into TcpPackage." {
 		t.Fail()
 	}
+
+	authenticated := client.NewTcpPackage(client.Command_Ping, client.FlagsAuthenticated, correlationId, data,
+		client.NewUserCredentials("user", "pass"))
+	p, err = client.TcpPacketFromBytes(authenticated.Bytes())
+	if err != nil {
+		t.Fatalf("TcpPacketFromBytes failed: %v", err)
+	}
+	if p.Username() != "user" || p.Password() != "pass" || !bytes.Equal(p.Data(), data) {
+		t.Errorf("Authenticated package doesn't match: %s %s %v", p.Username(), p.Password(), p.Data())
+	}
 }
\ No newline at end of file
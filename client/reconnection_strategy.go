@@ -0,0 +1,94 @@
+package client
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Consulted between reconnection attempts. attempt starts at 1 and lastError is the reason the previous TCP
+// connection was closed, if known. Returning false stops reconnecting and closes the connection.
+type ReconnectionStrategy interface {
+	NextDelay(attempt int, lastError error) (time.Duration, bool)
+}
+
+type ReconnectionStrategyFunc func(attempt int, lastError error) (time.Duration, bool)
+
+func (f ReconnectionStrategyFunc) NextDelay(attempt int, lastError error) (time.Duration, bool) {
+	return f(attempt, lastError)
+}
+
+type constantReconnectionStrategy struct {
+	delay time.Duration
+}
+
+func NewConstantReconnectionStrategy(delay time.Duration) ReconnectionStrategy {
+	if delay < 0 {
+		panic("delay should not be negative")
+	}
+	return &constantReconnectionStrategy{delay}
+}
+
+func (s *constantReconnectionStrategy) NextDelay(attempt int, lastError error) (time.Duration, bool) {
+	return s.delay, true
+}
+
+type exponentialReconnectionStrategy struct {
+	initialDelay time.Duration
+	maxDelay     time.Duration
+}
+
+// Doubles the delay after each attempt, starting at initialDelay, up to maxDelay.
+func NewExponentialReconnectionStrategy(initialDelay time.Duration, maxDelay time.Duration) ReconnectionStrategy {
+	if initialDelay <= 0 {
+		panic("initialDelay should be positive")
+	}
+	if maxDelay < initialDelay {
+		panic("maxDelay should not be less than initialDelay")
+	}
+	return &exponentialReconnectionStrategy{initialDelay, maxDelay}
+}
+
+func (s *exponentialReconnectionStrategy) NextDelay(attempt int, lastError error) (time.Duration, bool) {
+	delay := s.initialDelay
+	for i := 1; i < attempt && delay < s.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > s.maxDelay {
+		delay = s.maxDelay
+	}
+	return delay, true
+}
+
+type decorrelatedJitterReconnectionStrategy struct {
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	lock      sync.Mutex
+	lastDelay time.Duration
+}
+
+// Picks a random delay between baseDelay and three times the previous delay, up to maxDelay, so that many clients
+// don't reconnect in lockstep.
+func NewDecorrelatedJitterReconnectionStrategy(baseDelay time.Duration, maxDelay time.Duration) ReconnectionStrategy {
+	if baseDelay <= 0 {
+		panic("baseDelay should be positive")
+	}
+	if maxDelay < baseDelay {
+		panic("maxDelay should not be less than baseDelay")
+	}
+	return &decorrelatedJitterReconnectionStrategy{baseDelay: baseDelay, maxDelay: maxDelay}
+}
+
+func (s *decorrelatedJitterReconnectionStrategy) NextDelay(attempt int, lastError error) (time.Duration, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if attempt <= 1 || s.lastDelay < s.baseDelay {
+		s.lastDelay = s.baseDelay
+	}
+	delay := s.baseDelay + time.Duration(rand.Int63n(int64(s.lastDelay*3-s.baseDelay)+1))
+	if delay > s.maxDelay {
+		delay = s.maxDelay
+	}
+	s.lastDelay = delay
+	return delay, true
+}
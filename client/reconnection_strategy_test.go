@@ -0,0 +1,67 @@
+package client_test
+
+import (
+	"errors"
+	"github.com/jdextraze/go-gesclient/client"
+	"testing"
+	"time"
+)
+
+func TestConstantReconnectionStrategy(t *testing.T) {
+	s := client.NewConstantReconnectionStrategy(time.Second)
+	for attempt := 1; attempt < 5; attempt++ {
+		if delay, ok := s.NextDelay(attempt, nil); delay != time.Second || !ok {
+			t.Errorf("NextDelay(%d) doesn't match: %v %v", attempt, delay, ok)
+		}
+	}
+}
+
+func TestExponentialReconnectionStrategy(t *testing.T) {
+	s := client.NewExponentialReconnectionStrategy(100*time.Millisecond, time.Second)
+	expected := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	for i, e := range expected {
+		if delay, ok := s.NextDelay(i+1, nil); delay != e*time.Millisecond || !ok {
+			t.Errorf("NextDelay(%d) doesn't match: %v != %v", i+1, delay, e*time.Millisecond)
+		}
+	}
+	if delay, _ := s.NextDelay(1000, nil); delay != time.Second {
+		t.Errorf("NextDelay(1000) doesn't match: %v", delay)
+	}
+}
+
+func TestDecorrelatedJitterReconnectionStrategy(t *testing.T) {
+	s := client.NewDecorrelatedJitterReconnectionStrategy(100*time.Millisecond, time.Second)
+	last := 100 * time.Millisecond
+	for attempt := 1; attempt < 100; attempt++ {
+		delay, ok := s.NextDelay(attempt, nil)
+		if !ok || delay < 100*time.Millisecond || delay > time.Second || delay > 3*last {
+			t.Fatalf("NextDelay(%d) is out of range: %v (last %v)", attempt, delay, last)
+		}
+		last = delay
+	}
+}
+
+func TestReconnectionStrategyFunc(t *testing.T) {
+	expectedErr := errors.New("closed")
+	s := client.ReconnectionStrategyFunc(func(attempt int, lastError error) (time.Duration, bool) {
+		return time.Duration(attempt) * time.Second, lastError != expectedErr
+	})
+	if delay, ok := s.NextDelay(2, nil); delay != 2*time.Second || !ok {
+		t.Errorf("NextDelay doesn't match: %v %v", delay, ok)
+	}
+	if _, ok := s.NextDelay(1, expectedErr); ok {
+		t.Error("NextDelay should stop reconnecting")
+	}
+}
+
+func TestConnectionSettings_ReconnectionStrategy(t *testing.T) {
+	settings := client.CreateConnectionSettings().SetReconnectionDelayTo(time.Second).Build()
+	if delay, ok := settings.ReconnectionStrategy().NextDelay(1, nil); delay != time.Second || !ok {
+		t.Errorf("Default strategy doesn't match: %v %v", delay, ok)
+	}
+	strategy := client.NewExponentialReconnectionStrategy(time.Millisecond, time.Second)
+	settings = client.CreateConnectionSettings().SetReconnectionStrategy(strategy).Build()
+	if settings.ReconnectionStrategy() != strategy {
+		t.Error("ReconnectionStrategy doesn't match")
+	}
+}
@@ -61,6 +61,11 @@ func (e *ResolvedEvent) IsResolved() bool {
 	return e.link != nil && e.event != nil
 }
 
+// True for a resolved link whose event was deleted or truncated, in which case Event() is nil
+func (e *ResolvedEvent) IsLinkToDeletedEvent() bool {
+	return e.link != nil && e.event == nil
+}
+
 func (e *ResolvedEvent) OriginalStreamId() string {
 	return e.OriginalEvent().EventStreamId()
 }
@@ -0,0 +1,70 @@
+package client
+
+type RetryReason int
+
+const (
+	// The server asked for a retry or a reconnection, see OperationRetry.Decision and Description
+	RetryReason_Inspection RetryReason = iota
+	// No response was received within the operation timeout
+	RetryReason_Timeout
+	// The connection used by the operation was closed
+	RetryReason_ConnectionLost
+)
+
+var retryReasonValues = []string{
+	"Inspection",
+	"Timeout",
+	"ConnectionLost",
+}
+
+func (r RetryReason) String() string {
+	return retryReasonValues[r]
+}
+
+type OperationRetry struct {
+	// Request command of the operation, identifies the operation type
+	Command Command
+	// True when the operation can be safely repeated, like reads and appends where every event has an id
+	Idempotent  bool
+	Reason      RetryReason
+	Decision    InspectionDecision
+	Description string
+	// Number of retries already performed
+	RetryCount int
+	MaxRetries int
+}
+
+type RetryPolicy interface {
+	ShouldRetry(r *OperationRetry) bool
+}
+
+type RetryPolicyFunc func(r *OperationRetry) bool
+
+func (f RetryPolicyFunc) ShouldRetry(r *OperationRetry) bool {
+	return f(r)
+}
+
+// Retries until the operation max retries is reached, -1 meaning forever.
+var DefaultRetryPolicy RetryPolicy = RetryPolicyFunc(func(r *OperationRetry) bool {
+	return r.MaxRetries < 0 || r.RetryCount < r.MaxRetries
+})
+
+// Retries up to maxRetries times, regardless of the connection settings. -1 retries forever.
+func NewMaxRetriesPolicy(maxRetries int) RetryPolicy {
+	if maxRetries < -1 {
+		panic("maxRetries is out of range. Allowed range: [-1, infinity]")
+	}
+	return RetryPolicyFunc(func(r *OperationRetry) bool {
+		return maxRetries < 0 || r.RetryCount < maxRetries
+	})
+}
+
+// Fails non idempotent operations instead of retrying them, and delegates the others to policy.
+func NewIdempotentOnlyRetryPolicy(policy RetryPolicy) RetryPolicy {
+	if policy == nil {
+		panic("policy is nil")
+	}
+	return RetryPolicyFunc(func(r *OperationRetry) bool {
+		return r.Idempotent && policy.ShouldRetry(r)
+	})
+}
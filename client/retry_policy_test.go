@@ -0,0 +1,58 @@
+package client_test
+
+import (
+	"github.com/jdextraze/go-gesclient/client"
+	"testing"
+)
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	if !client.DefaultRetryPolicy.ShouldRetry(&client.OperationRetry{RetryCount: 9, MaxRetries: 10}) {
+		t.Error("Should retry below the max retries")
+	}
+	if client.DefaultRetryPolicy.ShouldRetry(&client.OperationRetry{RetryCount: 10, MaxRetries: 10}) {
+		t.Error("Should not retry when the max retries is reached")
+	}
+	if !client.DefaultRetryPolicy.ShouldRetry(&client.OperationRetry{RetryCount: 1000, MaxRetries: -1}) {
+		t.Error("Should always retry with -1 max retries")
+	}
+}
+
+func TestMaxRetriesPolicy(t *testing.T) {
+	policy := client.NewMaxRetriesPolicy(3)
+	if !policy.ShouldRetry(&client.OperationRetry{RetryCount: 2, MaxRetries: 0}) {
+		t.Error("Should ignore the operation max retries")
+	}
+	if policy.ShouldRetry(&client.OperationRetry{RetryCount: 3, MaxRetries: 10}) {
+		t.Error("Should not retry when the max retries is reached")
+	}
+}
+
+func TestIdempotentOnlyRetryPolicy(t *testing.T) {
+	policy := client.NewIdempotentOnlyRetryPolicy(client.NewMaxRetriesPolicy(-1))
+	if policy.ShouldRetry(&client.OperationRetry{Idempotent: false}) {
+		t.Error("Should not retry non idempotent operations")
+	}
+	if !policy.ShouldRetry(&client.OperationRetry{Idempotent: true}) {
+		t.Error("Should retry idempotent operations")
+	}
+}
+
+func TestConnectionSettings_RetryPolicy(t *testing.T) {
+	readPolicy := client.NewMaxRetriesPolicy(-1)
+	defaultPolicy := client.NewMaxRetriesPolicy(1)
+	builder := client.CreateConnectionSettings().
+		SetRetryPolicy(defaultPolicy).
+		SetRetryPolicyFor(client.Command_ReadEvent, readPolicy)
+	settings := builder.Build()
+	builder.SetRetryPolicyFor(client.Command_WriteEvents, readPolicy)
+
+	if !settings.RetryPolicy(client.Command_ReadEvent).ShouldRetry(&client.OperationRetry{RetryCount: 5}) {
+		t.Error("Command policy should be used")
+	}
+	if settings.RetryPolicy(client.Command_WriteEvents).ShouldRetry(&client.OperationRetry{RetryCount: 1}) {
+		t.Error("Default policy should be used, and built settings should not change with the builder")
+	}
+	if client.CreateConnectionSettings().Build().RetryPolicy(client.Command_WriteEvents) == nil {
+		t.Error("RetryPolicy should default to DefaultRetryPolicy")
+	}
+}
@@ -0,0 +1,17 @@
+package client
+
+type SendQueueOverflowPolicy int
+
+const (
+	SendQueueOverflowPolicy_Block SendQueueOverflowPolicy = iota
+	SendQueueOverflowPolicy_Fail
+)
+
+var sendQueueOverflowPolicyValues = []string{
+	"Block",
+	"Fail",
+}
+
+func (p SendQueueOverflowPolicy) String() string {
+	return sendQueueOverflowPolicyValues[p]
+}
@@ -0,0 +1,161 @@
+package client
+
+import (
+	"fmt"
+	"github.com/jdextraze/go-gesclient/common"
+	"strings"
+)
+
+const streamIteratorPageSize = 500
+
+// Iterates over the streams linked in $streams by the $streams system projection, in the order they were created.
+//
+//	it := client.NewStreamIterator(connection, "account-", true, userCredentials)
+//	for it.Next() {
+//		fmt.Println(it.Stream())
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type StreamIterator struct {
+	connection      Connection
+	prefix          string
+	skipDeleted     bool
+	userCredentials *UserCredentials
+	next            int
+	page            []*ResolvedEvent
+	endOfStream     bool
+	seen            map[string]bool
+	stream          string
+	err             error
+}
+
+// Only the streams whose name starts with prefix are returned. When skipDeleted is set, the streams that were deleted,
+// or whose events were all truncated or expired, are skipped.
+func NewStreamIterator(
+	connection Connection,
+	prefix string,
+	skipDeleted bool,
+	userCredentials *UserCredentials,
+) *StreamIterator {
+	if connection == nil {
+		panic("connection is nil")
+	}
+	return &StreamIterator{
+		connection:      connection,
+		prefix:          prefix,
+		skipDeleted:     skipDeleted,
+		userCredentials: userCredentials,
+		seen:            map[string]bool{},
+	}
+}
+
+// Returns the streams of the category, named <category>-<id> as expected by the $by_category system projection
+func NewCategoryStreamIterator(
+	connection Connection,
+	category string,
+	skipDeleted bool,
+	userCredentials *UserCredentials,
+) *StreamIterator {
+	if category == "" {
+		panic("category must be present")
+	}
+	return NewStreamIterator(connection, category+"-", skipDeleted, userCredentials)
+}
+
+// Advances to the next stream. It returns false at the end of $streams or when an error occurred, see Err.
+func (it *StreamIterator) Next() bool {
+	for it.err == nil {
+		if len(it.page) == 0 {
+			if it.endOfStream {
+				return false
+			}
+			it.err = it.readPage()
+			continue
+		}
+		evt := it.page[0]
+		it.page = it.page[1:]
+		stream := linkedStreamOf(evt)
+		if stream == "" || it.seen[stream] || !strings.HasPrefix(stream, it.prefix) {
+			continue
+		}
+		it.seen[stream] = true
+		if it.skipDeleted && !evt.IsResolved() {
+			deleted, err := it.isDeleted(stream)
+			if err != nil {
+				it.err = err
+				return false
+			}
+			if deleted {
+				continue
+			}
+		}
+		it.stream = stream
+		return true
+	}
+	return false
+}
+
+func (it *StreamIterator) Stream() string { return it.stream }
+
+func (it *StreamIterator) Err() error { return it.err }
+
+func (it *StreamIterator) readPage() error {
+	task, err := it.connection.ReadStreamEventsForwardAsync(common.SystemStreams_StreamsStream, it.next,
+		streamIteratorPageSize, true, it.userCredentials)
+	if err != nil {
+		return err
+	}
+	if err := task.Error(); err != nil {
+		return err
+	}
+	slice := task.Result().(*StreamEventsSlice)
+	switch slice.Status() {
+	case SliceReadStatus_Success:
+	case SliceReadStatus_StreamNotFound:
+		it.endOfStream = true
+		return nil
+	default:
+		return fmt.Errorf("Failed reading %s: %s", common.SystemStreams_StreamsStream, slice.Status())
+	}
+	it.page = slice.Events()
+	it.next = slice.NextEventNumber()
+	it.endOfStream = slice.IsEndOfStream()
+	return nil
+}
+
+// A link that cannot be resolved only tells that the first event is gone, so the last event of the stream is read to
+// tell a deleted stream from a truncated one
+func (it *StreamIterator) isDeleted(stream string) (bool, error) {
+	task, err := it.connection.ReadEventAsync(stream, -1, false, it.userCredentials)
+	if err != nil {
+		return false, err
+	}
+	if err := task.Error(); err != nil {
+		return false, err
+	}
+	switch status := task.Result().(*EventReadResult).Status(); status {
+	case EventReadStatus_Success:
+		return false, nil
+	case EventReadStatus_StreamDeleted, EventReadStatus_NoStream, EventReadStatus_NotFound:
+		return true, nil
+	default:
+		return false, fmt.Errorf("Failed reading last event of %s: %s", stream, status)
+	}
+}
+
+// The body of a link is "<event number>@<stream>"
+func linkedStreamOf(evt *ResolvedEvent) string {
+	if evt.Event() != nil {
+		return evt.Event().EventStreamId()
+	}
+	link := evt.Link()
+	if link == nil || link.EventType() != common.SystemEventTypes_LinkTo {
+		return ""
+	}
+	parts := strings.SplitN(string(link.Data()), "@", 2)
+	if len(parts) != 2 {
+		return ""
+	}
+	return parts[1]
+}
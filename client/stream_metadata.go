@@ -6,6 +6,8 @@ import (
 	"time"
 )
 
+// Values are the ones sent to the server: $maxAge and $cacheControl are expressed in seconds. Use the typed getters
+// to read them back, whether the metadata was built or decoded from JSON.
 type StreamMetadata map[string]interface{}
 
 func newStreamMetadata(
@@ -19,27 +21,27 @@ func newStreamMetadata(
 	if maxCount != nil && *maxCount <= 0 {
 		panic(fmt.Sprintf("maxCount should be positive value"))
 	}
-	if maxAge != nil && *maxAge <= 0 {
-		panic(fmt.Sprintf("maxAge should be positive value"))
+	if maxAge != nil && *maxAge < time.Second {
+		panic(fmt.Sprintf("maxAge should be at least one second"))
 	}
 	if truncateBefore != nil && *truncateBefore < 0 {
-		panic(fmt.Sprintf("maxCount should be non-negative value"))
+		panic(fmt.Sprintf("truncateBefore should be non-negative value"))
 	}
-	if cacheControl != nil && *cacheControl <= 0 {
-		panic(fmt.Sprintf("maxAge should be positive value"))
+	if cacheControl != nil && *cacheControl < time.Second {
+		panic(fmt.Sprintf("cacheControl should be at least one second"))
 	}
 	m := StreamMetadata{}
 	if maxCount != nil {
-		m["$maxCount"] = maxCount
+		m["$maxCount"] = *maxCount
 	}
 	if maxAge != nil {
-		m["$maxAge"] = maxAge
+		m["$maxAge"] = int64(*maxAge / time.Second)
 	}
 	if truncateBefore != nil {
-		m["$tb"] = truncateBefore
+		m["$tb"] = *truncateBefore
 	}
 	if cacheControl != nil {
-		m["$cacheControl"] = cacheControl
+		m["$cacheControl"] = int64(*cacheControl / time.Second)
 	}
 	if acl != nil {
 		m["$acl"] = acl
@@ -64,3 +66,87 @@ func StreamMetadataFromJsonBytes(data []byte) (StreamMetadata, error) {
 	metadata := StreamMetadata{}
 	return metadata, json.Unmarshal(data, &metadata)
 }
+
+func (m StreamMetadata) MaxCount() (int, bool) {
+	v, ok := metadataInt(m["$maxCount"])
+	return int(v), ok
+}
+
+func (m StreamMetadata) MaxAge() (time.Duration, bool) {
+	v, ok := metadataInt(m["$maxAge"])
+	return time.Duration(v) * time.Second, ok
+}
+
+func (m StreamMetadata) TruncateBefore() (int, bool) {
+	v, ok := metadataInt(m["$tb"])
+	return int(v), ok
+}
+
+func (m StreamMetadata) CacheControl() (time.Duration, bool) {
+	v, ok := metadataInt(m["$cacheControl"])
+	return time.Duration(v) * time.Second, ok
+}
+
+// Returns nil when the metadata has no valid ACL
+func (m StreamMetadata) Acl() *StreamAcl {
+	switch v := m["$acl"].(type) {
+	case nil:
+		return nil
+	case *StreamAcl:
+		return v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil
+		}
+		acl := &StreamAcl{}
+		if err := json.Unmarshal(data, acl); err != nil {
+			return nil
+		}
+		return acl
+	}
+}
+
+func (m StreamMetadata) CustomString(key string) (string, bool) {
+	v, ok := m[key].(string)
+	return v, ok
+}
+
+func (m StreamMetadata) CustomInt(key string) (int64, bool) {
+	return metadataInt(m[key])
+}
+
+func (m StreamMetadata) CustomBool(key string) (bool, bool) {
+	v, ok := m[key].(bool)
+	return v, ok
+}
+
+// Decodes the JSON value of a custom property into v
+func (m StreamMetadata) CustomJson(key string, v interface{}) error {
+	value, found := m[key]
+	if !found {
+		return fmt.Errorf("Custom property '%s' not found", key)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// Numbers are int or int64 when built and float64 when decoded from JSON
+func metadataInt(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int64:
+		return n, true
+	case float64:
+		return int64(n), true
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type StreamMetadataBuilder struct {
+	maxCount       *int
+	maxAge         *time.Duration
+	truncateBefore *int
+	cacheControl   *time.Duration
+	aclRead        []string
+	aclWrite       []string
+	aclDelete      []string
+	aclMetaRead    []string
+	aclMetaWrite   []string
+	customMetadata map[string]interface{}
+}
+
+func CreateStreamMetadataBuilder() *StreamMetadataBuilder {
+	return &StreamMetadataBuilder{
+		customMetadata: map[string]interface{}{},
+	}
+}
+
+// Starts from existing metadata, for example the one returned by GetStreamMetadataAsync
+func StreamMetadataBuilderFrom(metadata StreamMetadata) *StreamMetadataBuilder {
+	b := CreateStreamMetadataBuilder()
+	if v, ok := metadata.MaxCount(); ok {
+		b.SetMaxCount(v)
+	}
+	if v, ok := metadata.MaxAge(); ok {
+		b.SetMaxAge(v)
+	}
+	if v, ok := metadata.TruncateBefore(); ok {
+		b.SetTruncateBefore(v)
+	}
+	if v, ok := metadata.CacheControl(); ok {
+		b.SetCacheControl(v)
+	}
+	if acl := metadata.Acl(); acl != nil {
+		b.aclRead = acl.ReadRoles()
+		b.aclWrite = acl.WriteRoles()
+		b.aclDelete = acl.DeleteRoles()
+		b.aclMetaRead = acl.MetaReadRoles()
+		b.aclMetaWrite = acl.MetaWriteRoles()
+	}
+	for k, v := range metadata {
+		switch k {
+		case "$maxCount", "$maxAge", "$tb", "$cacheControl", "$acl":
+		default:
+			b.customMetadata[k] = v
+		}
+	}
+	return b
+}
+
+func (b *StreamMetadataBuilder) SetMaxCount(maxCount int) *StreamMetadataBuilder {
+	b.maxCount = &maxCount
+	return b
+}
+
+// Must be at least a second, as it is rounded down to the second
+func (b *StreamMetadataBuilder) SetMaxAge(maxAge time.Duration) *StreamMetadataBuilder {
+	b.maxAge = &maxAge
+	return b
+}
+
+func (b *StreamMetadataBuilder) SetTruncateBefore(truncateBefore int) *StreamMetadataBuilder {
+	b.truncateBefore = &truncateBefore
+	return b
+}
+
+// Must be at least a second, as it is rounded down to the second
+func (b *StreamMetadataBuilder) SetCacheControl(cacheControl time.Duration) *StreamMetadataBuilder {
+	b.cacheControl = &cacheControl
+	return b
+}
+
+func (b *StreamMetadataBuilder) SetReadRoles(roles ...string) *StreamMetadataBuilder {
+	b.aclRead = roles
+	return b
+}
+
+func (b *StreamMetadataBuilder) SetWriteRoles(roles ...string) *StreamMetadataBuilder {
+	b.aclWrite = roles
+	return b
+}
+
+func (b *StreamMetadataBuilder) SetDeleteRoles(roles ...string) *StreamMetadataBuilder {
+	b.aclDelete = roles
+	return b
+}
+
+func (b *StreamMetadataBuilder) SetMetadataReadRoles(roles ...string) *StreamMetadataBuilder {
+	b.aclMetaRead = roles
+	return b
+}
+
+func (b *StreamMetadataBuilder) SetMetadataWriteRoles(roles ...string) *StreamMetadataBuilder {
+	b.aclMetaWrite = roles
+	return b
+}
+
+func (b *StreamMetadataBuilder) SetCustomPropertyString(key string, value string) *StreamMetadataBuilder {
+	b.customMetadata[key] = value
+	return b
+}
+
+func (b *StreamMetadataBuilder) SetCustomPropertyInt(key string, value int64) *StreamMetadataBuilder {
+	b.customMetadata[key] = value
+	return b
+}
+
+func (b *StreamMetadataBuilder) SetCustomPropertyFloat(key string, value float64) *StreamMetadataBuilder {
+	b.customMetadata[key] = value
+	return b
+}
+
+func (b *StreamMetadataBuilder) SetCustomPropertyBool(key string, value bool) *StreamMetadataBuilder {
+	b.customMetadata[key] = value
+	return b
+}
+
+// value must be valid JSON
+func (b *StreamMetadataBuilder) SetCustomPropertyJson(key string, value []byte) *StreamMetadataBuilder {
+	b.customMetadata[key] = json.RawMessage(value)
+	return b
+}
+
+func (b *StreamMetadataBuilder) RemoveCustomProperty(key string) *StreamMetadataBuilder {
+	delete(b.customMetadata, key)
+	return b
+}
+
+func (b *StreamMetadataBuilder) Build() StreamMetadata {
+	var acl *StreamAcl
+	if b.aclRead != nil || b.aclWrite != nil || b.aclDelete != nil || b.aclMetaRead != nil || b.aclMetaWrite != nil {
+		acl = NewStreamAcl(b.aclRead, b.aclWrite, b.aclDelete, b.aclMetaRead, b.aclMetaWrite)
+	}
+	return newStreamMetadata(b.maxCount, b.maxAge, b.truncateBefore, b.cacheControl, acl, b.customMetadata)
+}
@@ -0,0 +1,118 @@
+package client_test
+
+import (
+	"encoding/json"
+	"github.com/jdextraze/go-gesclient/client"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStreamMetadataBuilder_Build(t *testing.T) {
+	metadata := client.CreateStreamMetadataBuilder().
+		SetMaxCount(10).
+		SetMaxAge(2*time.Hour).
+		SetTruncateBefore(5).
+		SetCacheControl(time.Minute).
+		SetReadRoles("$all").
+		SetWriteRoles("admin", "writer").
+		SetCustomPropertyString("owner", "billing").
+		SetCustomPropertyInt("version", 3).
+		SetCustomPropertyBool("archived", false).
+		SetCustomPropertyJson("tags", []byte(`["a","b"]`)).
+		Build()
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"$acl":{"$r":"$all","$w":["admin","writer"]},"$cacheControl":60,"$maxAge":7200,"$maxCount":10,` +
+		`"$tb":5,"archived":false,"owner":"billing","tags":["a","b"],"version":3}`
+	if string(data) != expected {
+		t.Errorf("JSON doesn't match: %s != %s", data, expected)
+	}
+
+	decoded, err := client.StreamMetadataFromJsonBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []client.StreamMetadata{metadata, decoded} {
+		if v, ok := m.MaxCount(); !ok || v != 10 {
+			t.Errorf("MaxCount doesn't match: %d != 10", v)
+		}
+		if v, ok := m.MaxAge(); !ok || v != 2*time.Hour {
+			t.Errorf("MaxAge doesn't match: %s != 2h", v)
+		}
+		if v, ok := m.TruncateBefore(); !ok || v != 5 {
+			t.Errorf("TruncateBefore doesn't match: %d != 5", v)
+		}
+		if v, ok := m.CacheControl(); !ok || v != time.Minute {
+			t.Errorf("CacheControl doesn't match: %s != 1m", v)
+		}
+		acl := m.Acl()
+		if acl == nil || !reflect.DeepEqual(acl.ReadRoles(), []string{"$all"}) ||
+			!reflect.DeepEqual(acl.WriteRoles(), []string{"admin", "writer"}) || acl.DeleteRoles() != nil {
+			t.Errorf("Acl doesn't match: %+v", acl)
+		}
+		if v, ok := m.CustomString("owner"); !ok || v != "billing" {
+			t.Errorf("CustomString doesn't match: %s != billing", v)
+		}
+		if v, ok := m.CustomInt("version"); !ok || v != 3 {
+			t.Errorf("CustomInt doesn't match: %d != 3", v)
+		}
+		if v, ok := m.CustomBool("archived"); !ok || v {
+			t.Errorf("CustomBool doesn't match: %t != false", v)
+		}
+		var tags []string
+		if err := m.CustomJson("tags", &tags); err != nil || !reflect.DeepEqual(tags, []string{"a", "b"}) {
+			t.Errorf("CustomJson doesn't match: %v, %v", tags, err)
+		}
+		if _, ok := m.CustomString("missing"); ok {
+			t.Error("CustomString should not find a missing property")
+		}
+	}
+
+	rebuilt, _ := json.Marshal(client.StreamMetadataBuilderFrom(decoded).Build())
+	if string(rebuilt) != expected {
+		t.Errorf("Rebuilt JSON doesn't match: %s != %s", rebuilt, expected)
+	}
+}
+
+func TestStreamMetadata_EmptyGetters(t *testing.T) {
+	m := client.CreateStreamMetadataBuilder().Build()
+	if _, ok := m.MaxAge(); ok {
+		t.Error("MaxAge should not be set")
+	}
+	if m.Acl() != nil {
+		t.Error("Acl should be nil")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Build should panic when maxCount is not positive")
+		}
+	}()
+	client.CreateStreamMetadataBuilder().SetMaxCount(0).Build()
+}
+
+func TestStreamMetadataBuilder_SubSecondDurations(t *testing.T) {
+	for name, build := range map[string]func(b *client.StreamMetadataBuilder){
+		"maxAge":       func(b *client.StreamMetadataBuilder) { b.SetMaxAge(500 * time.Millisecond) },
+		"cacheControl": func(b *client.StreamMetadataBuilder) { b.SetCacheControl(500 * time.Millisecond) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Build should panic when %s is under a second", name)
+				}
+			}()
+			b := client.CreateStreamMetadataBuilder()
+			build(b)
+			b.Build()
+		}()
+	}
+
+	m := client.CreateStreamMetadataBuilder().SetMaxAge(1500 * time.Millisecond).Build()
+	if v, ok := m.MaxAge(); !ok || v != time.Second {
+		t.Errorf("MaxAge doesn't match: %s != 1s", v)
+	}
+}
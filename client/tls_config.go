@@ -0,0 +1,45 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+// Builds a TLS configuration trusting the certificate authorities in caFile and presenting the certificate in
+// certFile/keyFile to the server. Empty paths are ignored.
+func NewTlsConfig(caFile string, certFile string, keyFile string) (*tls.Config, error) {
+	config := &tls.Config{}
+	if err := addTlsFiles(config, caFile, certFile, keyFile); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func addTlsFiles(config *tls.Config, caFile string, certFile string, keyFile string) error {
+	if caFile != "" {
+		pem, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return err
+		}
+		if config.RootCAs == nil {
+			config.RootCAs = x509.NewCertPool()
+		}
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("No certificate found in %s", caFile)
+		}
+	}
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return errors.New("Both certificate and key files must be present")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return err
+		}
+		config.Certificates = append(config.Certificates, cert)
+	}
+	return nil
+}
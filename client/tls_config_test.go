@@ -0,0 +1,185 @@
+package client_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/jdextraze/go-gesclient/client"
+	"github.com/satori/go.uuid"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeCertificate(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+	return certFile, keyFile
+}
+
+func TestNewTlsConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certFile, keyFile := writeCertificate(t, dir)
+
+	config, err := client.NewTlsConfig(certFile, certFile, keyFile)
+	if err != nil {
+		t.Fatalf("NewTlsConfig failed: %v", err)
+	}
+	if config.RootCAs == nil {
+		t.Error("RootCAs should be set")
+	}
+	if len(config.Certificates) != 1 {
+		t.Errorf("Certificates length doesn't match: %d != 1", len(config.Certificates))
+	}
+
+	if _, err := client.NewTlsConfig(keyFile, "", ""); err == nil {
+		t.Error("NewTlsConfig should fail when the CA file contains no certificate")
+	}
+	if _, err := client.NewTlsConfig("", certFile, ""); err == nil {
+		t.Error("NewTlsConfig should fail when the key file is missing")
+	}
+	if _, err := client.NewTlsConfig(filepath.Join(dir, "missing.pem"), "", ""); err == nil {
+		t.Error("NewTlsConfig should fail when the CA file doesn't exist")
+	}
+}
+
+func TestConnectionSettingsBuilder_TlsFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certFile, keyFile := writeCertificate(t, dir)
+
+	builder := client.CreateConnectionSettings().UseSslConnection("localhost", true)
+	if _, err := builder.UseRootCertificateAuthority(certFile); err != nil {
+		t.Fatalf("UseRootCertificateAuthority failed: %v", err)
+	}
+	if _, err := builder.UseClientCertificate(certFile, keyFile); err != nil {
+		t.Fatalf("UseClientCertificate failed: %v", err)
+	}
+	settings := builder.Build()
+	if settings.TlsConfig() == nil || settings.TlsConfig().RootCAs == nil ||
+		len(settings.TlsConfig().Certificates) != 1 {
+		t.Errorf("TlsConfig doesn't match: %+v", settings.TlsConfig())
+	}
+
+	builder = client.CreateConnectionSettings()
+	if _, err := builder.UseRootCertificateAuthority(filepath.Join(dir, "missing.pem")); err == nil {
+		t.Error("UseRootCertificateAuthority should fail when the file doesn't exist")
+	}
+	if _, err := builder.UseClientCertificate(certFile, ""); err == nil {
+		t.Error("UseClientCertificate should fail when the key file is missing")
+	}
+	if settings := builder.Build(); settings.TlsConfig() != nil {
+		t.Errorf("TlsConfig should be nil: %+v", settings.TlsConfig())
+	}
+}
+
+func TestParseConnectionString_TlsFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certFile, keyFile := writeCertificate(t, dir)
+
+	settings, _, err := client.ParseConnectionString("ConnectTo=ssl://localhost:1115; TlsCAFile=" + certFile +
+		"; TlsCertFile=" + certFile + "; TlsKeyFile=" + keyFile)
+	if err != nil {
+		t.Fatalf("ParseConnectionString failed: %v", err)
+	}
+	if settings.TlsConfig() == nil || settings.TlsConfig().RootCAs == nil ||
+		len(settings.TlsConfig().Certificates) != 1 {
+		t.Errorf("TlsConfig doesn't match: %+v", settings.TlsConfig())
+	}
+
+	for _, connectionString := range []string{
+		"ConnectTo=ssl://localhost:1115; TlsCAFile=" + filepath.Join(dir, "missing.pem"),
+		"ConnectTo=ssl://localhost:1115; TlsCertFile=" + certFile,
+		"ssl://localhost:1115/?tlsKeyFile=" + keyFile + "&tlsCertFile=" + keyFile,
+	} {
+		if _, _, err := client.ParseConnectionString(connectionString); err == nil {
+			t.Errorf("ParseConnectionString should fail for '%s'", connectionString)
+		}
+	}
+}
+
+func TestPackageConnection_MutualTls(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certFile, keyFile := writeCertificate(t, dir)
+
+	config, err := client.NewTlsConfig(certFile, certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: config.Certificates,
+		ClientCAs:    config.RootCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	handshake := make(chan error, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			handshake <- err
+			return
+		}
+		defer conn.Close()
+		handshake <- conn.(*tls.Conn).Handshake()
+	}()
+
+	c := client.NewPackageConnection(l.Addr(), uuid.Must(uuid.NewV4()), true, "localhost", true, config,
+		time.Second, client.DefaultSendQueueSize, client.SendQueueOverflowPolicy_Block, 0,
+		func(conn *client.PackageConnection, p *client.Package) {}, nil, nil, nil)
+	if err := c.StartReceiving(); err != nil {
+		t.Fatalf("StartReceiving failed: %v", err)
+	}
+	if err := <-handshake; err != nil {
+		t.Errorf("Handshake failed: %v", err)
+	}
+}
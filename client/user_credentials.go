@@ -16,3 +16,10 @@ func (uc *UserCredentials) Username() string {
 func (uc *UserCredentials) Password() string {
 	return uc.password
 }
+
+func (uc *UserCredentials) Equal(o *UserCredentials) bool {
+	if uc == nil || o == nil {
+		return uc == o
+	}
+	return uc.username == o.username && uc.password == o.password
+}
@@ -25,6 +25,8 @@ const (
 	SystemStreams_StreamsStream     = "$streams"
 	SystemStreams_SettingsStream    = "$settings"
 	SystemStreams_StatsStreamPrefix = "$stats"
+	SystemStreams_CategoryPrefix    = "$ce-"
+	SystemStreams_EventTypePrefix   = "$et-"
 
 	SystemEventTypes_StreamDeleted   = "$streamDeleted"
 	SystemEventTypes_StatsCollection = "$statsCollected"
@@ -44,3 +46,13 @@ func SystemStreams_IsMetastream(stream string) bool {
 func SystemStreams_OriginalStreamOf(stream string) string {
 	return stream[2:]
 }
+
+// Stream of links to the events of the category, maintained by the $by_category system projection
+func SystemStreams_CategoryStreamOf(category string) string {
+	return SystemStreams_CategoryPrefix + category
+}
+
+// Stream of links to the events of the type, maintained by the $by_event_type system projection
+func SystemStreams_EventTypeStreamOf(eventType string) string {
+	return SystemStreams_EventTypePrefix + eventType
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/jdextraze/go-gesclient/internal"
 	"net"
 	"net/url"
+	"strconv"
+	"strings"
 )
 
 func Create(settings *client.ConnectionSettings, uri *url.URL, name string) (client.Connection, error) {
@@ -29,6 +31,11 @@ func Create(settings *client.ConnectionSettings, uri *url.URL, name string) (cli
 		connectionSettings.DefaultUserCredentials = credentials
 	}
 
+	connectionSettings, err := applyTlsOptionsFromUri(connectionSettings, uri)
+	if err != nil {
+		return nil, err
+	}
+
 	var endPointDiscoverer internal.EndpointDiscoverer
 	if scheme == "discover" {
 		clusterSettings := client.NewClusterSettings(getUrlHostname(uri), connectionSettings.MaxDiscoverAttempts(),
@@ -41,12 +48,6 @@ func Create(settings *client.ConnectionSettings, uri *url.URL, name string) (cli
 			clusterSettings.GossipSeeds(),
 			clusterSettings.GossipTimeout())
 	} else if scheme == "tcp" || scheme == "ssl" {
-		if scheme == "ssl" {
-			connectionSettings = client.ConnectionSettingsBuilderFrom(connectionSettings).
-				UseSslConnection(getUrlHostname(uri), true).
-				Build()
-		}
-
 		tcpEndpoint, err := net.ResolveTCPAddr("tcp", uri.Host)
 		if err != nil {
 			return nil, err
@@ -68,6 +69,15 @@ func Create(settings *client.ConnectionSettings, uri *url.URL, name string) (cli
 	return internal.NewConnection(connectionSettings, nil, endPointDiscoverer, name), nil
 }
 
+// Creates a connection from a connection string. See client.ParseConnectionString for the supported formats.
+func CreateFromConnectionString(connectionString string, name string) (client.Connection, error) {
+	settings, uri, err := client.ParseConnectionString(connectionString)
+	if err != nil {
+		return nil, err
+	}
+	return Create(settings, uri, name)
+}
+
 func getCredentialsFromUri(uri *url.URL) *client.UserCredentials {
 	if uri == nil || uri.User == nil {
 		return nil
@@ -75,3 +85,54 @@ func getCredentialsFromUri(uri *url.URL) *client.UserCredentials {
 	password, _ := uri.User.Password()
 	return client.NewUserCredentials(uri.User.Username(), password)
 }
+
+// Supported query parameters: tls (true to use SSL with the tcp scheme), tlsVerifyCert, tlsServerName, tlsCAFile,
+// tlsCertFile and tlsKeyFile. The ssl scheme implies tls=true, unless the settings already use SSL.
+func applyTlsOptionsFromUri(
+	settings *client.ConnectionSettings,
+	uri *url.URL,
+) (*client.ConnectionSettings, error) {
+	if uri == nil || uri.RawQuery == "" && settings.UseSslConnection() {
+		return settings, nil
+	}
+
+	useTls := uri.Scheme == "ssl"
+	verifyCert := true
+	serverName := getUrlHostname(uri)
+	var caFile, certFile, keyFile string
+	for key, values := range uri.Query() {
+		value := values[len(values)-1]
+		var err error
+		switch strings.ToLower(key) {
+		case "tls":
+			useTls, err = strconv.ParseBool(value)
+		case "tlsverifycert":
+			verifyCert, err = strconv.ParseBool(value)
+		case "tlsservername":
+			serverName = value
+		case "tlscafile":
+			caFile = value
+		case "tlscertfile":
+			certFile = value
+		case "tlskeyfile":
+			keyFile = value
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value for '%s': %v", key, err)
+		}
+	}
+	if !useTls {
+		return settings, nil
+	}
+
+	builder := client.ConnectionSettingsBuilderFrom(settings).UseSslConnection(serverName, verifyCert)
+	if _, err := builder.UseRootCertificateAuthority(caFile); err != nil {
+		return nil, err
+	}
+	if _, err := builder.UseClientCertificate(certFile, keyFile); err != nil {
+		return nil, err
+	}
+	return builder.Build(), nil
+}
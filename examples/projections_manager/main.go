@@ -71,7 +71,13 @@ func main() {
 		log.Fatalf("Failed resolving tcp address: %v", err)
 	}
 
-	manager = projections.NewManager(addr, time.Second*3)
+	settings := projections.CreateManagerSettings().
+		SetHttpEndpoint(addr).
+		SetOperationTimeoutTo(time.Second * 3)
+	if httpUrl.Scheme == "https" {
+		settings.UseHttps(nil)
+	}
+	manager = projections.NewManagerFromSettings(settings.Build())
 
 	command.handler()
 }
@@ -137,16 +137,29 @@ func (d *ClusterDnsEndpointDiscoverer) resolveDns(dns string) ([]net.IP, error)
 func (d *ClusterDnsEndpointDiscoverer) tryGetGossipFrom(endpoint *client.GossipSeed) *messages.ClusterInfoDto {
 	url := fmt.Sprintf("http://%s/gossip?format=json", endpoint.IpEndpoint().String())
 	log.Infof("Trying to get gossip from %s", url)
-	resp, err := http.Get(url)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return nil
 	}
+	if endpoint.HostHeader() != "" {
+		req.Host = endpoint.HostHeader()
+	}
+	resp, err := (&http.Client{Timeout: d.gossipTimeout}).Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
 	data, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return nil
 	}
 	clusterInfoDto := &messages.ClusterInfoDto{}
-	json.Unmarshal(data, clusterInfoDto)
+	if err := json.Unmarshal(data, clusterInfoDto); err != nil {
+		return nil
+	}
 	return clusterInfoDto
 }
 
@@ -161,13 +174,18 @@ func (d *ClusterDnsEndpointDiscoverer) tryDetermineBestNode(members []*messages.
 		node = n
 		break
 	}
+	if node == nil {
+		return nil
+	}
 	normTcp := &net.TCPAddr{IP: net.ParseIP(node.ExternalTcpIp), Port: node.ExternalTcpPort}
 	var secTcp net.Addr
 	if node.ExternalSecureTcpPort > 0 {
 		secTcp = &net.TCPAddr{IP: net.ParseIP(node.ExternalTcpIp), Port: node.ExternalSecureTcpPort}
 	}
 	log.Infof("Discovering: found best choice [%s,%s] (%s)", normTcp, secTcp, node.State)
-	return NewNodeEndpoints(normTcp, secTcp)
+	endpoints := NewNodeEndpoints(normTcp, secTcp)
+	endpoints.httpEndpoint = &net.TCPAddr{IP: net.ParseIP(node.ExternalHttpIp), Port: node.ExternalHttpPort}
+	return endpoints
 }
 
 func (d *ClusterDnsEndpointDiscoverer) arrangeGossipCandidates(
@@ -189,19 +207,13 @@ func (d *ClusterDnsEndpointDiscoverer) arrangeGossipCandidates(
 	}
 	d.randomShuffle(result, 0, i)
 	d.randomShuffle(result, j, len(members)-1)
-	return nil
+	return result
 }
 
 func (d *ClusterDnsEndpointDiscoverer) randomShuffle(arr []*client.GossipSeed, i int, j int) {
-	if i >= j {
-		return
-	}
-	perm := rand.Perm(j + 1)
-	for k := i; k <= j; k++ {
-		index := perm[k]
-		tmp := arr[index]
-		arr[index] = arr[k]
-		arr[k] = tmp
+	for k := j; k > i; k-- {
+		index := i + rand.Intn(k-i+1)
+		arr[index], arr[k] = arr[k], arr[index]
 	}
 }
 
@@ -209,4 +221,4 @@ type byStateDescending []*messages.MemberInfoDto
 
 func (a byStateDescending) Len() int           { return len(a) }
 func (a byStateDescending) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-func (a byStateDescending) Less(i, j int) bool { return a[i].State < a[j].State }
+func (a byStateDescending) Less(i, j int) bool { return a[i].State > a[j].State }
@@ -0,0 +1,49 @@
+package internal_test
+
+import (
+	"fmt"
+	"github.com/jdextraze/go-gesclient/client"
+	"github.com/jdextraze/go-gesclient/internal"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClusterDnsEndpointDiscoverer_DiscoverAsync(t *testing.T) {
+	var addr *net.TCPAddr
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintf(w, `{"Members": [
+			{"State": "Slave", "IsAlive": true, "ExternalTcpIp": "127.0.0.1", "ExternalTcpPort": 1001,
+				"ExternalHttpIp": "127.0.0.1", "ExternalHttpPort": %[1]d},
+			{"State": "Shutdown", "IsAlive": true, "ExternalTcpIp": "127.0.0.1", "ExternalTcpPort": 1002,
+				"ExternalHttpIp": "127.0.0.1", "ExternalHttpPort": %[1]d},
+			{"State": 7, "IsAlive": true, "ExternalTcpIp": "127.0.0.1", "ExternalTcpPort": 1003,
+				"ExternalHttpIp": "127.0.0.1", "ExternalHttpPort": %[1]d},
+			{"State": "Manager", "IsAlive": true, "ExternalHttpIp": "127.0.0.1", "ExternalHttpPort": %[1]d}
+		]}`, addr.Port)
+	}))
+	defer server.Close()
+	addr = server.Listener.Addr().(*net.TCPAddr)
+
+	discoverer := internal.NewClusterDnsEndPointDiscoverer("", 1, 0,
+		[]*client.GossipSeed{client.NewGossipSeed(addr, "")}, time.Second)
+
+	// The second discovery gets its candidates from the gossip of the first one
+	for i := 0; i < 2; i++ {
+		task := discoverer.DiscoverAsync(nil)
+		if err := task.Error(); err != nil {
+			t.Fatalf("Discovery %d failed: %v", i, err)
+		}
+		endpoints := task.Result().(*internal.NodeEndpoints)
+		if endpoints.TcpEndpoint().String() != "127.0.0.1:1003" {
+			t.Errorf("Discovery %d should pick the master: %s", i, endpoints)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("Expected a gossip request per discovery: %d", requests)
+	}
+}
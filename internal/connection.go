@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -62,6 +63,23 @@ func (c *connection) Close() error {
 	return c.handler.EnqueueMessage(newCloseConnectionMessage("Connection close requested by client.", nil))
 }
 
+func (c *connection) CloseGracefully(ctx context.Context) error {
+	msg := newStartDrainingMessage()
+	if err := c.handler.EnqueueMessage(msg); err != nil {
+		return err
+	}
+	var err error
+	select {
+	case <-msg.drained:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if closeErr := c.Close(); closeErr != nil {
+		return closeErr
+	}
+	return err
+}
+
 func (c *connection) DeleteStreamAsync(
 	stream string,
 	expectedVersion int,
@@ -71,6 +89,9 @@ func (c *connection) DeleteStreamAsync(
 	if stream == "" {
 		return nil, errors.New("stream must be present")
 	}
+	if err := client.ExpectedVersion(expectedVersion).Validate(); err != nil {
+		return nil, err
+	}
 	source := tasks.NewCompletionSource()
 	op := operations.NewDeleteStream(source, stream, expectedVersion, hardDelete, userCredentials)
 	return source.Task(), c.enqueueOperation(op)
@@ -88,12 +109,170 @@ func (c *connection) AppendToStreamAsync(
 	if events == nil {
 		panic("events is nil")
 	}
+	if err := client.ExpectedVersion(expectedVersion).Validate(); err != nil {
+		return nil, err
+	}
 	source := tasks.NewCompletionSource()
 	op := operations.NewAppendToStream(source, c.connectionSettings.RequireMaster(), stream, expectedVersion, events,
 		userCredentials)
 	return source.Task(), c.enqueueOperation(op)
 }
 
+func (c *connection) ConditionalAppendToStreamAsync(
+	stream string,
+	expectedVersion int,
+	events []*client.EventData,
+	userCredentials *client.UserCredentials,
+) (*tasks.Task, error) {
+	if stream == "" {
+		panic("stream is empty")
+	}
+	if events == nil {
+		panic("events is nil")
+	}
+	if err := client.ExpectedVersion(expectedVersion).Validate(); err != nil {
+		return nil, err
+	}
+	source := tasks.NewCompletionSource()
+	op := operations.NewConditionalAppendToStream(source, c.connectionSettings.RequireMaster(), stream,
+		expectedVersion, events, userCredentials)
+	return source.Task(), c.enqueueOperation(op)
+}
+
+func (c *connection) AppendLargeAsync(
+	stream string,
+	expectedVersion int,
+	events []*client.EventData,
+	maxBatchBytes int,
+	userCredentials *client.UserCredentials,
+) (*tasks.Task, error) {
+	if maxBatchBytes <= 0 {
+		panic("maxBatchBytes should be positive")
+	}
+	batches := batchEvents(events, maxBatchBytes)
+	if len(batches) <= 1 {
+		return c.AppendToStreamAsync(stream, expectedVersion, events, userCredentials)
+	}
+	t, err := c.StartTransactionAsync(stream, expectedVersion, userCredentials)
+	if err != nil {
+		return nil, err
+	}
+	return t.ContinueWith(func(t *tasks.Task) (interface{}, error) {
+		if t.Error() != nil {
+			return nil, t.Error()
+		}
+		transaction := t.Result().(*client.Transaction)
+		for _, batch := range batches {
+			task, err := transaction.WriteAsync(batch)
+			if err == nil {
+				err = task.Wait()
+			}
+			if err != nil {
+				transaction.Rollback()
+				return nil, err
+			}
+		}
+		task, err := transaction.CommitAsync()
+		if err != nil {
+			return nil, err
+		}
+		return task.Result(), task.Error()
+	}), nil
+}
+
+// Splits events in batches of at most maxBatchBytes. An event larger than maxBatchBytes is sent in its own batch.
+func batchEvents(events []*client.EventData, maxBatchBytes int) [][]*client.EventData {
+	var (
+		batches [][]*client.EventData
+		batch   []*client.EventData
+		size    int
+	)
+	for _, e := range events {
+		if len(batch) > 0 && size+e.Size() > maxBatchBytes {
+			batches = append(batches, batch)
+			batch = nil
+			size = 0
+		}
+		batch = append(batch, e)
+		size += e.Size()
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+func (c *connection) LinkToAsync(
+	targetStream string,
+	expectedVersion int,
+	events []*client.RecordedEvent,
+	userCredentials *client.UserCredentials,
+) (*tasks.Task, error) {
+	links := make([]*client.EventData, len(events))
+	for i, evt := range events {
+		if evt == nil {
+			return nil, fmt.Errorf("event %d is nil", i)
+		}
+		links[i] = client.NewLinkEventData(targetStream, evt)
+	}
+	return c.AppendToStreamAsync(targetStream, expectedVersion, links, userCredentials)
+}
+
+func (c *connection) AppendIdempotentAsync(
+	stream string,
+	expectedVersion int,
+	events []*client.EventData,
+	userCredentials *client.UserCredentials,
+) (*tasks.Task, error) {
+	for _, e := range events {
+		if uuid.Equal(e.EventId(), uuid.Nil) {
+			return nil, errors.New("events must have an event id")
+		}
+	}
+	t, err := c.AppendToStreamAsync(stream, expectedVersion, events, userCredentials)
+	if err != nil {
+		return nil, err
+	}
+	return t.ContinueWith(func(t *tasks.Task) (interface{}, error) {
+		if t.Error() == client.WrongExpectedVersion {
+			return c.checkAlreadyAppended(stream, events, userCredentials)
+		} else if t.Error() != nil {
+			return nil, t.Error()
+		}
+		res := t.Result().(*client.WriteResult)
+		// The server answers with no log position when all the events were already committed
+		duplicate := res.LogPosition().Equals(client.NewPosition(-1, -1))
+		return client.NewIdempotentWriteResult(res, duplicate), nil
+	}), nil
+}
+
+// Reads the end of the stream and reports a duplicate when it contains the events, in order
+func (c *connection) checkAlreadyAppended(
+	stream string,
+	events []*client.EventData,
+	userCredentials *client.UserCredentials,
+) (interface{}, error) {
+	t, err := c.ReadStreamEventsBackwardAsync(stream, -1, len(events), false, userCredentials)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.Wait(); err != nil {
+		return nil, err
+	}
+	slice := t.Result().(*client.StreamEventsSlice)
+	read := slice.Events()
+	if slice.Status() != client.SliceReadStatus_Success || len(read) != len(events) {
+		return nil, client.WrongExpectedVersion
+	}
+	for i, e := range events {
+		if !uuid.Equal(read[len(read)-1-i].OriginalEvent().EventId(), e.EventId()) {
+			return nil, client.WrongExpectedVersion
+		}
+	}
+	return client.NewIdempotentWriteResult(client.NewWriteResult(slice.LastEventNumber(), client.NewPosition(-1, -1)),
+		true), nil
+}
+
 func (c *connection) StartTransactionAsync(
 	stream string,
 	expectedVersion int,
@@ -102,6 +281,9 @@ func (c *connection) StartTransactionAsync(
 	if stream == "" {
 		panic("stream is empty")
 	}
+	if err := client.ExpectedVersion(expectedVersion).Validate(); err != nil {
+		return nil, err
+	}
 	source := tasks.NewCompletionSource()
 	op := operations.NewStartTransaction(source, c.connectionSettings.RequireMaster(), stream, expectedVersion, c,
 		userCredentials)
@@ -191,6 +373,58 @@ func (c *connection) ReadStreamEventsBackwardAsync(
 	return source.Task(), c.enqueueOperation(op)
 }
 
+func (c *connection) ReadCategoryEventsForwardAsync(
+	category string,
+	start int,
+	max int,
+	userCredentials *client.UserCredentials,
+) (*tasks.Task, error) {
+	if category == "" {
+		return nil, errors.New("category must be present")
+	}
+	return c.ReadStreamEventsForwardAsync(common.SystemStreams_CategoryStreamOf(category), start, max, true,
+		userCredentials)
+}
+
+func (c *connection) ReadCategoryEventsBackwardAsync(
+	category string,
+	start int,
+	max int,
+	userCredentials *client.UserCredentials,
+) (*tasks.Task, error) {
+	if category == "" {
+		return nil, errors.New("category must be present")
+	}
+	return c.ReadStreamEventsBackwardAsync(common.SystemStreams_CategoryStreamOf(category), start, max, true,
+		userCredentials)
+}
+
+func (c *connection) ReadEventTypeEventsForwardAsync(
+	eventType string,
+	start int,
+	max int,
+	userCredentials *client.UserCredentials,
+) (*tasks.Task, error) {
+	if eventType == "" {
+		return nil, errors.New("eventType must be present")
+	}
+	return c.ReadStreamEventsForwardAsync(common.SystemStreams_EventTypeStreamOf(eventType), start, max, true,
+		userCredentials)
+}
+
+func (c *connection) ReadEventTypeEventsBackwardAsync(
+	eventType string,
+	start int,
+	max int,
+	userCredentials *client.UserCredentials,
+) (*tasks.Task, error) {
+	if eventType == "" {
+		return nil, errors.New("eventType must be present")
+	}
+	return c.ReadStreamEventsBackwardAsync(common.SystemStreams_EventTypeStreamOf(eventType), start, max, true,
+		userCredentials)
+}
+
 func (c *connection) ReadAllEventsForwardAsync(
 	position *client.Position,
 	max int,
@@ -282,6 +516,49 @@ func (c *connection) SubscribeToAllFrom(
 	return sub, nil
 }
 
+func (c *connection) SubscribeToCategoryFrom(
+	category string,
+	lastCheckpoint *int,
+	settings *client.CatchUpSubscriptionSettings,
+	eventAppeared client.CatchUpEventAppearedHandler,
+	liveProcessingStarted client.LiveProcessingStartedHandler,
+	subscriptionDropped client.CatchUpSubscriptionDroppedHandler,
+	userCredentials *client.UserCredentials,
+) (client.CatchUpSubscription, error) {
+	if category == "" {
+		return nil, errors.New("category must be present")
+	}
+	return c.SubscribeToStreamFrom(common.SystemStreams_CategoryStreamOf(category), lastCheckpoint,
+		resolvingLinkTos(settings), eventAppeared, liveProcessingStarted, subscriptionDropped, userCredentials)
+}
+
+func (c *connection) SubscribeToEventTypeFrom(
+	eventType string,
+	lastCheckpoint *int,
+	settings *client.CatchUpSubscriptionSettings,
+	eventAppeared client.CatchUpEventAppearedHandler,
+	liveProcessingStarted client.LiveProcessingStartedHandler,
+	subscriptionDropped client.CatchUpSubscriptionDroppedHandler,
+	userCredentials *client.UserCredentials,
+) (client.CatchUpSubscription, error) {
+	if eventType == "" {
+		return nil, errors.New("eventType must be present")
+	}
+	return c.SubscribeToStreamFrom(common.SystemStreams_EventTypeStreamOf(eventType), lastCheckpoint,
+		resolvingLinkTos(settings), eventAppeared, liveProcessingStarted, subscriptionDropped, userCredentials)
+}
+
+func resolvingLinkTos(settings *client.CatchUpSubscriptionSettings) *client.CatchUpSubscriptionSettings {
+	if settings == nil {
+		settings = client.CatchUpSubscriptionSettings_Default
+	}
+	if settings.ResolveLinkTos() {
+		return settings
+	}
+	return client.NewCatchUpSubscriptionSettings(settings.MaxLiveQueueSize(), settings.ReadBatchSize(),
+		settings.VerboseLogging(), true)
+}
+
 func (c *connection) ConnectToPersistentSubscriptionAsync(
 	stream string,
 	groupName string,
@@ -340,6 +617,9 @@ func (c *connection) SetStreamMetadataAsync(
 	if common.SystemStreams_IsMetastream(stream) {
 		panic(fmt.Errorf("Setting metadata for metastream '%s' is not supported.", stream))
 	}
+	if err := client.ExpectedVersion(expectedMetastreamVersion).Validate(); err != nil {
+		return nil, err
+	}
 	source := tasks.NewCompletionSource()
 	var metaevent *client.EventData
 	switch metadata.(type) {
@@ -379,13 +659,17 @@ func (c *connection) GetStreamMetadataAsync(
 				return nil, errors.New("Event is nil while operation result is Success.")
 			}
 			evt := res.Event().OriginalEvent()
-			if evt == nil || evt.Data() == nil || len(evt.Data()) == 0 {
+			if evt == nil {
 				return client.NewStreamMetadataResult(res.Stream(), false, -1, client.StreamMetadata{}), nil
 			}
+			if evt.Data() == nil || len(evt.Data()) == 0 {
+				return client.NewStreamMetadataResult(res.Stream(), false, evt.EventNumber(), client.StreamMetadata{}),
+					nil
+			}
 			if metadata, err := client.StreamMetadataFromJsonBytes(evt.Data()); err != nil {
 				return nil, err
 			} else {
-				return client.NewStreamMetadataResult(res.Stream(), false, -1, metadata), nil
+				return client.NewStreamMetadataResult(res.Stream(), false, evt.EventNumber(), metadata), nil
 			}
 		case client.EventReadStatus_NotFound, client.EventReadStatus_NoStream:
 			return client.NewStreamMetadataResult(res.Stream(), false, -1, client.StreamMetadata{}), nil
@@ -397,6 +681,46 @@ func (c *connection) GetStreamMetadataAsync(
 	}), nil
 }
 
+func (c *connection) UpdateStreamMetadataAsync(
+	stream string,
+	update func(metadata client.StreamMetadata) client.StreamMetadata,
+	userCredentials *client.UserCredentials,
+) (*tasks.Task, error) {
+	if stream == "" {
+		panic("stream is empty")
+	}
+	if common.SystemStreams_IsMetastream(stream) {
+		panic(fmt.Errorf("Setting metadata for metastream '%s' is not supported.", stream))
+	}
+	if update == nil {
+		panic("update is nil")
+	}
+	return tasks.NewStarted(func() (interface{}, error) {
+		for retry := 0; ; retry++ {
+			t, err := c.GetStreamMetadataAsync(stream, userCredentials)
+			if err != nil {
+				return nil, err
+			}
+			if err := t.Wait(); err != nil {
+				return nil, err
+			}
+			res := t.Result().(*client.StreamMetadataResult)
+			if res.IsStreamDeleted() {
+				return nil, client.StreamDeleted
+			}
+			t, err = c.SetStreamMetadataAsync(stream, res.MetastreamVersion(), update(res.StreamMetadata()),
+				userCredentials)
+			if err != nil {
+				return nil, err
+			}
+			maxRetries := c.connectionSettings.MaxRetries()
+			if err := t.Wait(); err != client.WrongExpectedVersion || maxRetries >= 0 && retry >= maxRetries {
+				return t.Result(), err
+			}
+		}
+	}), nil
+}
+
 func (c *connection) SetSystemSettings(
 	settings *client.SystemSettings,
 	userCredentials *client.UserCredentials,
@@ -417,10 +741,18 @@ func (c *connection) enqueueOperation(op client.Operation) error {
 		}
 		time.Sleep(time.Millisecond)
 	}
-	return c.handler.EnqueueMessage(newStartOperationMessage(op, c.connectionSettings.MaxReconnections(),
+	return c.handler.EnqueueMessage(newStartOperationMessage(op, c.connectionSettings.MaxRetries(),
 		c.connectionSettings.OperationTimeout()))
 }
 
+func (c *connection) SendQueueLength() int {
+	return c.handler.SendQueueLength()
+}
+
+func (c *connection) State() *client.ConnectionState {
+	return c.handler.State()
+}
+
 func (c *connection) Settings() *client.ConnectionSettings {
 	return c.connectionSettings
 }
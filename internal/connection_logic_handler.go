@@ -16,6 +16,8 @@ import (
 
 type ConnectionLogicHandler interface {
 	TotalOperationCount() int
+	SendQueueLength() int
+	State() *client.ConnectionState
 	EnqueueMessage(msg message) error
 	Connected() client.EventHandlers
 	Disconnected() client.EventHandlers
@@ -34,6 +36,8 @@ type heartbeatInfo struct {
 type reconnectionInfo struct {
 	ReconnectionAttempt int
 	Timestamp           time.Duration
+	Delay               time.Duration
+	Reconnect           bool
 }
 
 type authInfo struct {
@@ -41,37 +45,6 @@ type authInfo struct {
 	Timestamp     time.Duration
 }
 
-type connectionState int
-
-const (
-	connectionState_Init connectionState = iota
-	connectionState_Connecting
-	connectionState_Connected
-	connectionState_Closed
-)
-
-var connectionState_values = []string{
-	"Init",
-	"Connecting",
-	"Connected",
-	"Closed",
-}
-
-func (s connectionState) String() string {
-	return connectionState_values[s]
-}
-
-type connectingPhase int
-
-const (
-	connectingPhase_Invalid connectingPhase = iota
-	connectingPhase_Reconnecting
-	connectingPhase_EndpointDiscovery
-	connectingPhase_ConnectionEstablishing
-	connectingPhase_Authentication
-	connectingPhase_Connected
-)
-
 type connectionLogicHandler struct {
 	connected             *eventHandlers
 	disconnected          *eventHandlers
@@ -91,11 +64,17 @@ type connectionLogicHandler struct {
 	lastTimeoutsTimestamp time.Duration
 	operations            *OperationsManager
 	subscriptions         *SubscriptionsManager
-	state                 connectionState
-	connectingPhase       connectingPhase
+	state                 client.ConnectionStatus
+	connectingPhase       client.ConnectingPhase
 	wasConnected          int32
 	packageNumber         int
 	connection            *client.PackageConnection
+	currentConnection     atomic.Value
+	stateSnapshot         atomic.Value
+	authCredentials       *client.UserCredentials
+	draining              bool
+	unsubscribed          bool
+	drainWaiters          []chan struct{}
 }
 
 func NewConnectionLogicHandler(
@@ -124,27 +103,30 @@ func NewConnectionLogicHandler(
 		startTime:            time.Now(),
 		operations:           NewOperationsManager(connection.Name(), settings),
 		subscriptions:        NewSubscriptionManager(connection.Name(), settings),
-		connectingPhase:      connectingPhase_Invalid,
+		connectingPhase:      client.ConnectingPhase_Invalid,
 	}
 
-	queue.RegisterHandler(&startConnectionMessage{}, obj.startConnection)
-	queue.RegisterHandler(&closeConnectionMessage{}, obj.closeConnection)
+	obj.register(&startConnectionMessage{}, obj.startConnection)
+	obj.register(&closeConnectionMessage{}, obj.closeConnection)
+	obj.register(&startDrainingMessage{}, obj.startDraining)
 
-	queue.RegisterHandler(&startOperationMessage{}, obj.startOperation)
-	queue.RegisterHandler(&startSubscriptionMessage{}, obj.startSubscription)
-	queue.RegisterHandler(&startPersistentSubscriptionMessage{}, obj.startPersistentSubscription)
+	obj.register(&startOperationMessage{}, obj.startOperation)
+	obj.register(&startSubscriptionMessage{}, obj.startSubscription)
+	obj.register(&startPersistentSubscriptionMessage{}, obj.startPersistentSubscription)
 
-	queue.RegisterHandler(&establishTcpConnectionMessage{}, obj.establishTcpConnection)
-	queue.RegisterHandler(&tcpConnectionEstablishedMessage{}, obj.tcpConnectionEstablished)
-	queue.RegisterHandler(&tcpConnectionErrorMessage{}, obj.tcpConnectionError)
-	queue.RegisterHandler(&tcpConnectionClosedMessage{}, obj.tcpConnectionClosed)
-	queue.RegisterHandler(&handleTcpPackageMessage{}, obj.handleTcpPackage)
+	obj.register(&establishTcpConnectionMessage{}, obj.establishTcpConnection)
+	obj.register(&tcpConnectionEstablishedMessage{}, obj.tcpConnectionEstablished)
+	obj.register(&tcpConnectionErrorMessage{}, obj.tcpConnectionError)
+	obj.register(&tcpConnectionClosedMessage{}, obj.tcpConnectionClosed)
+	obj.register(&handleTcpPackageMessage{}, obj.handleTcpPackage)
 
-	queue.RegisterHandler(&timerTickMessage{}, obj.timerTick)
+	obj.register(&timerTickMessage{}, obj.timerTick)
+	obj.snapshotState()
 
-	obj.timer = time.NewTicker(client.TimerPeriod)
+	timer := time.NewTicker(client.TimerPeriod)
+	obj.timer = timer
 	go func() {
-		for range obj.timer.C {
+		for range timer.C {
 			obj.EnqueueMessage(&timerTickMessage{})
 		}
 	}()
@@ -156,6 +138,58 @@ func (h *connectionLogicHandler) TotalOperationCount() int {
 	return h.operations.TotalOperationCount()
 }
 
+func (h *connectionLogicHandler) SendQueueLength() int {
+	if c, ok := h.currentConnection.Load().(*client.PackageConnection); ok && c != nil {
+		return c.SendQueueLength()
+	}
+	return 0
+}
+
+// Subscriptions get the connection from their own goroutine when they unsubscribe
+func (h *connectionLogicHandler) getConnection() (*client.PackageConnection, error) {
+	c, _ := h.currentConnection.Load().(*client.PackageConnection)
+	return c, nil
+}
+
+// Returns the snapshot taken after the last handled message without waiting on the queue, so it never blocks even
+// when the connection is busy or stalled
+func (h *connectionLogicHandler) State() *client.ConnectionState {
+	state := *h.stateSnapshot.Load().(*client.ConnectionState)
+	state.SendQueueLength = h.SendQueueLength()
+	return &state
+}
+
+// Handlers are followed by a snapshot of the state, which timer ticks keep fresh
+func (h *connectionLogicHandler) register(msg message, handler messageHandler) {
+	h.queue.RegisterHandler(msg, func(msg message) error {
+		defer h.snapshotState()
+		return handler(msg)
+	})
+}
+
+func (h *connectionLogicHandler) snapshotState() {
+	state := &client.ConnectionState{
+		Status:                    h.state,
+		ConnectingPhase:           h.connectingPhase,
+		ReconnectionAttempt:       h.reconInfo.ReconnectionAttempt,
+		WaitingOperations:         h.operations.WaitingOperationCount(),
+		ActiveOperations:          h.operations.ActiveOperationCount(),
+		RetryPendingOperations:    h.operations.RetryPendingOperationCount(),
+		WaitingSubscriptions:      h.subscriptions.WaitingSubscriptionCount(),
+		ActiveSubscriptions:       h.subscriptions.ActiveSubscriptionCount(),
+		RetryPendingSubscriptions: h.subscriptions.RetryPendingSubscriptionCount(),
+	}
+	if h.connection != nil {
+		state.RemoteEndpoint = h.connection.RemoteEndpoint()
+		state.LocalEndpoint = h.connection.LocalEndpoint()
+		state.ConnectionId = h.connection.ConnectionId()
+		if h.connectingPhase > client.ConnectingPhase_ConnectionEstablishing {
+			state.SinceLastHeartbeat = h.elapsedTime() - h.heartbeatInfo.Timestamp
+		}
+	}
+	h.stateSnapshot.Store(state)
+}
+
 func (h *connectionLogicHandler) EnqueueMessage(msg message) error {
 	_, isTimerTickMessage := msg.(*timerTickMessage)
 	if h.settings.VerboseLogging() && !isTimerTickMessage {
@@ -174,16 +208,16 @@ func (h *connectionLogicHandler) startConnection(msg message) error {
 	}
 	log.Debug("Start connection")
 	switch h.state {
-	case connectionState_Init:
+	case client.ConnectionStatus_Init:
 		h.endpointDiscoverer = startConnectionMessage.endpointDiscoverer
-		h.state = connectionState_Connecting
-		h.connectingPhase = connectingPhase_Reconnecting
+		h.state = client.ConnectionStatus_Connecting
+		h.connectingPhase = client.ConnectingPhase_Reconnecting
 		h.discoverEndpoint(startConnectionMessage.task)
 		return nil
-	case connectionState_Connecting, connectionState_Connected:
+	case client.ConnectionStatus_Connecting, client.ConnectionStatus_Connected:
 		return startConnectionMessage.task.SetError(fmt.Errorf(
 			"EventStoreConnection '%s' is already active", h.esConnection.Name()))
-	case connectionState_Closed:
+	case client.ConnectionStatus_Closed:
 		return startConnectionMessage.task.SetError(fmt.Errorf(
 			"EventStoreConnection '%s' is closed", h.esConnection.Name()))
 	default:
@@ -194,14 +228,14 @@ func (h *connectionLogicHandler) startConnection(msg message) error {
 func (h *connectionLogicHandler) discoverEndpoint(task *tasks.CompletionSource) {
 	log.Debug("Discover endpoint")
 
-	if h.state != connectionState_Connecting {
+	if h.state != client.ConnectionStatus_Connecting {
 		return
 	}
-	if h.connectingPhase != connectingPhase_Reconnecting {
+	if h.connectingPhase != client.ConnectingPhase_Reconnecting {
 		return
 	}
 
-	h.connectingPhase = connectingPhase_EndpointDiscovery
+	h.connectingPhase = client.ConnectingPhase_EndpointDiscovery
 
 	var remoteEndpoint net.Addr
 	if h.connection != nil {
@@ -230,7 +264,7 @@ func (h *connectionLogicHandler) discoverEndpoint(task *tasks.CompletionSource)
 func (h *connectionLogicHandler) closeConnection(msg message) error {
 	m := msg.(*closeConnectionMessage)
 
-	if h.state == connectionState_Closed {
+	if h.state == client.ConnectionStatus_Closed {
 		log.Debugf("CloseConnection IGNORED because is ESConnection is CLOSED, reason %s, exception %v.",
 			m.reason, m.error)
 		return nil
@@ -238,7 +272,7 @@ func (h *connectionLogicHandler) closeConnection(msg message) error {
 
 	log.Debugf("CloseConnection, reason %s, exception %v.", m.reason, m.error)
 
-	h.state = connectionState_Closed
+	h.state = client.ConnectionStatus_Closed
 
 	h.timer.Stop()
 	h.timer = nil
@@ -258,6 +292,39 @@ func (h *connectionLogicHandler) closeConnection(msg message) error {
 
 	h.raiseClosed(m.reason)
 
+	return h.checkDrained()
+}
+
+func (h *connectionLogicHandler) startDraining(msg message) error {
+	m := msg.(*startDrainingMessage)
+	log.Debug("StartDraining")
+	h.draining = true
+	h.drainWaiters = append(h.drainWaiters, m.drained)
+	return h.checkDrained()
+}
+
+func (h *connectionLogicHandler) checkDrained() error {
+	if !h.draining || len(h.drainWaiters) == 0 {
+		return nil
+	}
+	if h.operations.ActiveOperationCount()+h.operations.WaitingOperationCount()+
+		h.operations.RetryPendingOperationCount() > 0 {
+		return nil
+	}
+	if !h.unsubscribed {
+		h.unsubscribed = true
+		if err := h.subscriptions.UnsubscribeAll(h.connection); err != nil {
+			return err
+		}
+	}
+	if h.subscriptions.ActiveSubscriptionCount() > 0 {
+		return nil
+	}
+	log.Debug("Drained")
+	for _, drained := range h.drainWaiters {
+		close(drained)
+	}
+	h.drainWaiters = nil
 	return nil
 }
 
@@ -269,23 +336,27 @@ func (h *connectionLogicHandler) closeTcpConnection(reason string) {
 
 	log.Debug("CloseTcpConnection")
 	h.connection.Close(reason)
-	h.tcpConnectionClosed(newTcpConnectionClosedMessage(h.connection, nil))
+	h.tcpConnectionClosed(newTcpConnectionClosedMessage(h.connection, errors.New(reason)))
 	h.connection = nil
+	h.currentConnection.Store(h.connection)
 }
 
 func (h *connectionLogicHandler) startOperation(msg message) error {
 	m := msg.(*startOperationMessage)
 
+	if h.draining && h.state != client.ConnectionStatus_Closed {
+		return m.operation.Fail(fmt.Errorf("EventStoreConnection '%s' is closing", h.esConnection.Name()))
+	}
 	switch h.state {
-	case connectionState_Init:
+	case client.ConnectionStatus_Init:
 		return m.operation.Fail(fmt.Errorf("EventStoreConnection '%s' is not active", h.esConnection.Name()))
-	case connectionState_Connecting:
+	case client.ConnectionStatus_Connecting:
 		log.Debugf("StartOperation enqueue %s, %d, %s", m.operation, m.maxRetries, m.timeout)
 		return h.operations.EnqueueOperation(newOperationItem(m.operation, m.maxRetries, m.timeout))
-	case connectionState_Connected:
+	case client.ConnectionStatus_Connected:
 		log.Debugf("StartOperation schedule %s, %d, %s", m.operation, m.maxRetries, m.timeout)
 		return h.operations.ScheduleOperation(newOperationItem(m.operation, m.maxRetries, m.timeout), h.connection)
-	case connectionState_Closed:
+	case client.ConnectionStatus_Closed:
 		return m.operation.Fail(fmt.Errorf("Connection %s is closed", h.esConnection.Name()))
 	default:
 		return fmt.Errorf("Unknown state: %s", h.state)
@@ -295,28 +366,35 @@ func (h *connectionLogicHandler) startOperation(msg message) error {
 func (h *connectionLogicHandler) startSubscription(msg message) error {
 	m := msg.(*startSubscriptionMessage)
 
+	if h.draining && h.state != client.ConnectionStatus_Closed {
+		return m.source.SetError(fmt.Errorf("EventStoreConnection '%s' is closing", h.esConnection.Name()))
+	}
 	switch h.state {
-	case connectionState_Init:
+	case client.ConnectionStatus_Init:
 		return m.source.SetError(fmt.Errorf("EventStoreConnection '%s' is not active.", h.esConnection.Name()))
-	case connectionState_Connecting, connectionState_Connected:
+	case client.ConnectionStatus_Connecting, client.ConnectionStatus_Connected:
+		userCredentials, err := h.operationCredentials(m.userCredentials)
+		if err != nil {
+			return m.source.SetError(err)
+		}
 		operation := subscriptions.NewVolatileSubscription(m.source, m.streamId, m.resolveLinkTos,
-			m.userCredentials, m.eventAppeared, m.subscriptionDropped, h.settings.VerboseLogging(),
-			func() (*client.PackageConnection, error) { return h.connection, nil })
+			userCredentials, m.eventAppeared, m.subscriptionDropped, h.settings.VerboseLogging(),
+			h.getConnection)
 		var state string
-		if h.state == connectionState_Connected {
+		if h.state == client.ConnectionStatus_Connected {
 			state = "fire"
 		} else {
 			state = "enqueue"
 		}
 		log.Debugf("StartSubscription %s %s, %d, %s", state, operation, m.maxRetries, m.timeout)
 		subscription := NewSubscriptionItem(operation, m.maxRetries, m.timeout)
-		if h.state == connectionState_Connecting {
+		if h.state == client.ConnectionStatus_Connecting {
 			h.subscriptions.EnqueueSubscription(subscription)
 		} else {
 			h.subscriptions.StartSubscription(subscription, h.connection)
 		}
 		return nil
-	case connectionState_Closed:
+	case client.ConnectionStatus_Closed:
 		return m.source.SetError(fmt.Errorf("Object disposed: %s", h.esConnection.Name()))
 	default:
 		return fmt.Errorf("Unknown state: %s", h.state)
@@ -325,28 +403,46 @@ func (h *connectionLogicHandler) startSubscription(msg message) error {
 
 func (h *connectionLogicHandler) startPersistentSubscription(msg message) error {
 	m := msg.(*startPersistentSubscriptionMessage)
+
+	if h.draining && h.state != client.ConnectionStatus_Closed {
+		return m.source.SetError(fmt.Errorf("EventStoreConnection '%s' is closing", h.esConnection.Name()))
+	}
 	switch h.state {
-	case connectionState_Init:
+	case client.ConnectionStatus_Init:
 		return m.source.SetError(fmt.Errorf("EventStoreConnection '%s' is not active.", h.esConnection.Name()))
-	case connectionState_Connecting, connectionState_Connected:
+	case client.ConnectionStatus_Connecting, client.ConnectionStatus_Connected:
+		userCredentials, err := h.operationCredentials(m.userCredentials)
+		if err != nil {
+			return m.source.SetError(err)
+		}
 		operation := subscriptions.NewConnectToPersistentSubscription(m.source, m.subscriptionId,
-			m.bufferSize, m.streamId, m.userCredentials, m.eventAppeared, m.subscriptionDropped,
-			h.settings.VerboseLogging(), func() (*client.PackageConnection, error) { return h.connection, nil })
+			m.bufferSize, m.streamId, userCredentials, m.eventAppeared, m.subscriptionDropped,
+			h.settings.VerboseLogging(), h.getConnection)
 		log.Debugf("StartSubscription %s %s, %d, %s", h.state, operation, m.maxRetries, m.timeout)
 		subscription := NewSubscriptionItem(operation, m.maxRetries, m.timeout)
-		if h.state == connectionState_Connecting {
+		if h.state == client.ConnectionStatus_Connecting {
 			h.subscriptions.EnqueueSubscription(subscription)
 		} else {
 			h.subscriptions.StartSubscription(subscription, h.connection)
 		}
 		return nil
-	case connectionState_Closed:
+	case client.ConnectionStatus_Closed:
 		return m.source.SetError(fmt.Errorf("Object disposed: %s", h.esConnection.Name()))
 	default:
 		return fmt.Errorf("Unknown state: %s", h.state)
 	}
 }
 
+// Operations started without credentials use the ones of the credentials provider, if any
+func (h *connectionLogicHandler) operationCredentials(
+	userCredentials *client.UserCredentials,
+) (*client.UserCredentials, error) {
+	if provider := h.settings.CredentialsProvider(); userCredentials == nil && provider != nil {
+		return provider.Credentials()
+	}
+	return userCredentials, nil
+}
+
 func (h *connectionLogicHandler) establishTcpConnection(msg message) error {
 	establishTcpConnection := msg.(*establishTcpConnectionMessage)
 	var tcpEndpoint net.Addr
@@ -362,15 +458,17 @@ func (h *connectionLogicHandler) establishTcpConnection(msg message) error {
 	if tcpEndpoint == nil {
 		h.closeConnection(newCloseConnectionMessage("No endpoint to node specified.", nil))
 	}
-	if h.state != connectionState_Connecting {
+	if h.state != client.ConnectionStatus_Connecting {
 		return nil
 	}
-	if h.connectingPhase != connectingPhase_EndpointDiscovery {
+	if h.connectingPhase != client.ConnectingPhase_EndpointDiscovery {
 		return nil
 	}
-	h.connectingPhase = connectingPhase_ConnectionEstablishing
+	h.connectingPhase = client.ConnectingPhase_ConnectionEstablishing
 	h.connection = client.NewPackageConnection(tcpEndpoint, uuid.Must(uuid.NewV4()), h.settings.UseSslConnection(),
-		h.settings.TargetHost(), h.settings.ValidateService(), h.settings.ClientConnectionTimeout(),
+		h.settings.TargetHost(), h.settings.ValidateService(), h.settings.TlsConfig(),
+		h.settings.ClientConnectionTimeout(), h.settings.SendQueueSize(), h.settings.SendQueueOverflowPolicy(),
+		h.settings.SendQueueTimeout(),
 		func(c *client.PackageConnection, p *client.Package) {
 			h.EnqueueMessage(newHandleTcpPackageMessage(c, p))
 		},
@@ -384,6 +482,7 @@ func (h *connectionLogicHandler) establishTcpConnection(msg message) error {
 			h.EnqueueMessage(newTcpConnectionClosedMessage(c, err))
 		},
 	)
+	h.currentConnection.Store(h.connection)
 	return h.connection.StartReceiving()
 }
 
@@ -393,30 +492,63 @@ func (h *connectionLogicHandler) elapsedTime() time.Duration {
 
 func (h *connectionLogicHandler) tcpConnectionEstablished(msg message) error {
 	m := msg.(*tcpConnectionEstablishedMessage)
-	if h.state != connectionState_Connecting || h.connection != m.connection || m.connection.IsClosed() {
+	if h.state != client.ConnectionStatus_Connecting || h.connection != m.connection || m.connection.IsClosed() {
 		log.Debugf("")
 		return nil
 	}
 
 	h.heartbeatInfo = heartbeatInfo{h.packageNumber, true, h.elapsedTime()}
 
-	if h.settings.DefaultUserCredentials != nil {
-		h.connectingPhase = connectingPhase_Authentication
-		h.authInfo = authInfo{uuid.Must(uuid.NewV4()), h.elapsedTime()}
-		return h.connection.EnqueueSend(client.NewTcpPackage(
-			client.Command_Authenticate,
-			client.FlagsAuthenticated,
-			h.authInfo.CorrelationId,
-			nil,
-			h.settings.DefaultUserCredentials,
-		))
+	credentials, err := h.connectionCredentials()
+	if err != nil {
+		h.raiseErrorOccurred(fmt.Errorf("Failed to get credentials: %v", err))
+	}
+	if credentials != nil {
+		h.connectingPhase = client.ConnectingPhase_Authentication
+		return h.authenticate(credentials)
 	}
 	return h.goToConnectedState()
 }
 
+func (h *connectionLogicHandler) connectionCredentials() (*client.UserCredentials, error) {
+	if provider := h.settings.CredentialsProvider(); provider != nil {
+		return provider.Credentials()
+	}
+	return h.settings.DefaultUserCredentials, nil
+}
+
+func (h *connectionLogicHandler) authenticate(credentials *client.UserCredentials) error {
+	h.authCredentials = credentials
+	h.authInfo = authInfo{uuid.Must(uuid.NewV4()), h.elapsedTime()}
+	return h.connection.EnqueueSend(client.NewTcpPackage(
+		client.Command_Authenticate,
+		client.FlagsAuthenticated,
+		h.authInfo.CorrelationId,
+		nil,
+		credentials,
+	))
+}
+
+func (h *connectionLogicHandler) checkCredentialsRotation() error {
+	provider := h.settings.CredentialsProvider()
+	if provider == nil {
+		return nil
+	}
+	credentials, err := provider.Credentials()
+	if err != nil {
+		log.Errorf("Failed to get credentials: %v", err)
+		return nil
+	}
+	if credentials == nil || credentials.Equal(h.authCredentials) {
+		return nil
+	}
+	log.Infof("EventStoreConnection '%s': credentials changed, authenticating again", h.esConnection.Name())
+	return h.authenticate(credentials)
+}
+
 func (h *connectionLogicHandler) goToConnectedState() error {
-	h.state = connectionState_Connected
-	h.connectingPhase = connectingPhase_Connected
+	h.state = client.ConnectionStatus_Connected
+	h.connectingPhase = client.ConnectingPhase_Connected
 
 	atomic.CompareAndSwapInt32(&h.wasConnected, 0, 1)
 
@@ -439,7 +571,7 @@ func (h *connectionLogicHandler) tcpConnectionError(msg message) error {
 	if h.connection != m.connection {
 		return nil
 	}
-	if h.state == connectionState_Closed {
+	if h.state == client.ConnectionStatus_Closed {
 		return nil
 	}
 	log.Debugf("TcpConnectionError connId %s, exc %v", m.connection.ConnectionId(), m.error)
@@ -448,10 +580,10 @@ func (h *connectionLogicHandler) tcpConnectionError(msg message) error {
 
 func (h *connectionLogicHandler) tcpConnectionClosed(msg message) error {
 	m := msg.(*tcpConnectionClosedMessage)
-	if h.state == connectionState_Init {
+	if h.state == client.ConnectionStatus_Init {
 		return errors.New(":|")
 	}
-	if h.state == connectionState_Closed || h.connection != m.connection {
+	if h.state == client.ConnectionStatus_Closed || h.connection != m.connection {
 		var cid uuid.UUID
 		if h.connection != nil {
 			cid = h.connection.ConnectionId()
@@ -461,14 +593,16 @@ func (h *connectionLogicHandler) tcpConnectionClosed(msg message) error {
 		return nil
 	}
 
-	h.state = connectionState_Connecting
-	h.connectingPhase = connectingPhase_Reconnecting
+	h.state = client.ConnectionStatus_Connecting
+	h.connectingPhase = client.ConnectingPhase_Reconnecting
 
 	log.Debugf("TCP connection to [%s, L%s, %s closed.", m.connection.RemoteEndpoint(),
 		m.connection.LocalEndpoint(), m.connection.ConnectionId())
 
 	h.subscriptions.PurgeSubscribedAndDroppedSubscriptions(h.connection.ConnectionId())
-	h.reconInfo = reconnectionInfo{h.reconInfo.ReconnectionAttempt, h.elapsedTime()}
+	delay, reconnect := h.settings.ReconnectionStrategy().NextDelay(h.reconInfo.ReconnectionAttempt+1,
+		m.socketError)
+	h.reconInfo = reconnectionInfo{h.reconInfo.ReconnectionAttempt, h.elapsedTime(), delay, reconnect}
 
 	if !atomic.CompareAndSwapInt32(&h.wasConnected, 1, 0) {
 		h.raiseDisconnected(m.connection.RemoteEndpoint())
@@ -478,11 +612,19 @@ func (h *connectionLogicHandler) tcpConnectionClosed(msg message) error {
 }
 
 func (h *connectionLogicHandler) handleTcpPackage(msg message) error {
+	// The package may complete the last operation or subscription of a draining connection
+	defer func() {
+		if err := h.checkDrained(); err != nil {
+			log.Errorf("Failed to check if the connection is drained: %v", err)
+		}
+	}()
+
 	m := msg.(*handleTcpPackageMessage)
 	command := m.pkg.Command()
 	correlationId := m.pkg.CorrelationId()
 
-	if h.connection != m.connection || h.state == connectionState_Closed || h.state == connectionState_Init {
+	if h.connection != m.connection || h.state == client.ConnectionStatus_Closed ||
+		h.state == client.ConnectionStatus_Init {
 		log.Debugf("IGNORED: HandleTcpPackage connId %s, package %s, %s.", m.connection.ConnectionId(),
 			command, correlationId)
 		return nil
@@ -501,8 +643,8 @@ func (h *connectionLogicHandler) handleTcpPackage(msg message) error {
 	}
 
 	if command == client.Command_Authenticated || command == client.Command_NotAuthenticated {
-		if h.state == connectionState_Connecting &&
-			h.connectingPhase == connectingPhase_Authentication &&
+		if h.state == client.ConnectionStatus_Connecting &&
+			h.connectingPhase == client.ConnectingPhase_Authentication &&
 			h.authInfo.CorrelationId == correlationId {
 			if command == client.Command_NotAuthenticated {
 				h.raiseAuthFailed("Not authenticated")
@@ -510,6 +652,12 @@ func (h *connectionLogicHandler) handleTcpPackage(msg message) error {
 			h.goToConnectedState()
 			return nil
 		}
+		if h.state == client.ConnectionStatus_Connected && h.authInfo.CorrelationId == correlationId {
+			if command == client.Command_NotAuthenticated {
+				h.raiseAuthFailed("Not authenticated")
+			}
+			return nil
+		}
 	}
 
 	if command == client.Command_BadRequest && correlationId == uuid.Nil {
@@ -536,18 +684,18 @@ func (h *connectionLogicHandler) handleTcpPackage(msg message) error {
 		case client.InspectionDecision_EndOperation:
 			h.operations.RemoveOperation(operation)
 		case client.InspectionDecision_Retry:
-			if err := h.operations.ScheduleOperationRetry(operation); err != nil {
+			if err := h.operations.ScheduleOperationRetry(operation, client.RetryReason_Inspection, result); err != nil {
 				return err
 			}
 		case client.InspectionDecision_Reconnect:
 			h.reconnectTo(NewNodeEndpoints(result.TcpEndpoint(), result.SecureTcpEndpoint()))
-			if err := h.operations.ScheduleOperationRetry(operation); err != nil {
+			if err := h.operations.ScheduleOperationRetry(operation, client.RetryReason_Inspection, result); err != nil {
 				return err
 			}
 		default:
 			return fmt.Errorf("Unknown InspectionDecision: %s", result.Decision())
 		}
-		if h.state == connectionState_Connected {
+		if h.state == client.ConnectionStatus_Connected {
 			return h.operations.TryScheduleWaitingOperations(m.connection)
 		}
 	} else if found, subscription := h.subscriptions.TryGetActiveSubscription(correlationId); found {
@@ -591,7 +739,7 @@ func (h *connectionLogicHandler) reconnectTo(endpoints *NodeEndpoints) {
 		h.closeConnection(newCloseConnectionMessage("No end point is specified while trying to reconnect.", nil))
 		return
 	}
-	if h.state != connectionState_Connected || h.connection.RemoteEndpoint().String() == endPoint.String() {
+	if h.state != client.ConnectionStatus_Connected || h.connection.RemoteEndpoint().String() == endPoint.String() {
 		return
 	}
 	msg := fmt.Sprintf("EventStoreConnection '%s': going to reconnect to [%s]. Current endpoint: [%s, L%s].",
@@ -601,48 +749,57 @@ func (h *connectionLogicHandler) reconnectTo(endpoints *NodeEndpoints) {
 	}
 	h.closeTcpConnection(msg)
 
-	h.state = connectionState_Connecting
-	h.connectingPhase = connectingPhase_EndpointDiscovery
+	h.state = client.ConnectionStatus_Connecting
+	h.connectingPhase = client.ConnectingPhase_EndpointDiscovery
 	h.establishTcpConnection(newEstablishTcpConnectionMessage(endpoints))
 }
 
 func (h *connectionLogicHandler) timerTick(msg message) error {
+	if err := h.checkDrained(); err != nil {
+		return err
+	}
 	switch h.state {
-	case connectionState_Init:
+	case client.ConnectionStatus_Init:
 		return nil
-	case connectionState_Connecting:
-		if h.connectingPhase == connectingPhase_Reconnecting && h.elapsedTime()-h.reconInfo.Timestamp >= h.settings.ReconnectionDelay() {
+	case client.ConnectionStatus_Connecting:
+		if h.connectingPhase == client.ConnectingPhase_Reconnecting && h.elapsedTime()-h.reconInfo.Timestamp >= h.reconInfo.Delay {
 			log.Debug("TimerTick checking reconnection")
 
-			h.reconInfo = reconnectionInfo{h.reconInfo.ReconnectionAttempt + 1, h.elapsedTime()}
+			h.reconInfo = reconnectionInfo{h.reconInfo.ReconnectionAttempt + 1, h.elapsedTime(), h.reconInfo.Delay,
+				h.reconInfo.Reconnect}
 			if h.settings.MaxReconnections() >= 0 && h.reconInfo.ReconnectionAttempt > h.settings.MaxReconnections() {
 				h.closeConnection(newCloseConnectionMessage("Reconnection limit reached.", nil))
+			} else if !h.reconInfo.Reconnect {
+				h.closeConnection(newCloseConnectionMessage("Reconnection stopped by the reconnection strategy.", nil))
 			} else {
 				h.raiseReconnecting()
 				h.discoverEndpoint(nil)
 			}
 		}
-		if h.connectingPhase == connectingPhase_Authentication && h.elapsedTime()-h.reconInfo.Timestamp >= h.settings.OperationTimeout() {
+		if h.connectingPhase == client.ConnectingPhase_Authentication && h.elapsedTime()-h.reconInfo.Timestamp >= h.settings.OperationTimeout() {
 			h.raiseAuthFailed("Authentication timed out.")
 			h.goToConnectedState()
 		}
-		if h.connectingPhase > connectingPhase_ConnectionEstablishing {
+		if h.connectingPhase > client.ConnectingPhase_ConnectionEstablishing {
 			return h.manageHeartbeats()
 		}
 		return nil
-	case connectionState_Connected:
+	case client.ConnectionStatus_Connected:
 		if h.elapsedTime()-h.lastTimeoutsTimestamp >= h.settings.OperationTimeoutCheckPeriod() {
-			h.reconInfo = reconnectionInfo{0, h.elapsedTime()}
+			h.reconInfo = reconnectionInfo{0, h.elapsedTime(), 0, true}
 			if err := h.operations.CheckTimeoutsAndRetry(h.connection); err != nil {
 				return err
 			}
 			if err := h.subscriptions.CheckTimeoutsAndRetry(h.connection); err != nil {
 				return err
 			}
+			if err := h.checkCredentialsRotation(); err != nil {
+				return err
+			}
 			h.lastTimeoutsTimestamp = h.elapsedTime()
 		}
 		return h.manageHeartbeats()
-	case connectionState_Closed:
+	case client.ConnectionStatus_Closed:
 		return nil
 	default:
 		return fmt.Errorf("Unknown state: %v", h.state)
@@ -6,9 +6,11 @@ import (
 	"github.com/jdextraze/go-gesclient/client"
 	"github.com/jdextraze/go-gesclient/log"
 	"reflect"
+	"sync"
 )
 
 type eventHandlers struct {
+	lock     sync.Mutex
 	handlers []client.EventHandler
 }
 
@@ -19,11 +21,15 @@ func newEventHandlers() *eventHandlers {
 }
 
 func (h *eventHandlers) Add(handler client.EventHandler) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
 	h.handlers = append(h.handlers, handler)
 	return nil
 }
 
 func (h *eventHandlers) Remove(handler client.EventHandler) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
 	pos := -1
 	for i, h := range h.handlers {
 		if fmt.Sprintf("%v", h) == fmt.Sprintf("%v", handler) {
@@ -39,8 +45,11 @@ func (h *eventHandlers) Remove(handler client.EventHandler) error {
 }
 
 func (h *eventHandlers) Raise(evt client.Event) {
+	h.lock.Lock()
+	handlers := append([]client.EventHandler(nil), h.handlers...)
+	h.lock.Unlock()
 	go func() {
-		for _, h := range h.handlers {
+		for _, h := range handlers {
 			if err := h(evt); err != nil {
 				log.Errorf("Error occurred while raising event %s: %v", reflect.TypeOf(evt), err)
 			}
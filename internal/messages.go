@@ -247,3 +247,15 @@ func newTcpConnectionErrorMessage(
 }
 
 func (m *tcpConnectionErrorMessage) MessageID() int { return 10 }
+
+type startDrainingMessage struct {
+	drained chan struct{}
+}
+
+func newStartDrainingMessage() *startDrainingMessage {
+	return &startDrainingMessage{
+		drained: make(chan struct{}),
+	}
+}
+
+func (m *startDrainingMessage) MessageID() int { return 12 }
@@ -8,6 +8,7 @@ import (
 type NodeEndpoints struct {
 	tcpEndpoint       net.Addr
 	secureTcpEndpoint net.Addr
+	httpEndpoint      net.Addr
 }
 
 func NewNodeEndpoints(
@@ -27,6 +28,9 @@ func (e *NodeEndpoints) TcpEndpoint() net.Addr { return e.tcpEndpoint }
 
 func (e *NodeEndpoints) SecureTcpEndpoint() net.Addr { return e.secureTcpEndpoint }
 
+// Only known when the endpoints were discovered through gossip
+func (e *NodeEndpoints) HttpEndpoint() net.Addr { return e.httpEndpoint }
+
 func (e *NodeEndpoints) String() string {
 	normal := "n/a"
 	secure := "n/a"
@@ -78,9 +78,11 @@ func (m *OperationsManager) CheckTimeoutsAndRetry(c *client.PackageConnection) e
 
 	var removeOperations []*operationItem
 	var retryOperations []*operationItem
+	var retryReasons []client.RetryReason
 	for _, o := range m.activeOperations {
 		if o.ConnectionId != c.ConnectionId() {
 			retryOperations = append(retryOperations, o)
+			retryReasons = append(retryReasons, client.RetryReason_ConnectionLost)
 		} else if o.timeout > time.Duration(0) && time.Now().UTC().Sub(o.LastUpdated) > m.settings.OperationTimeout() {
 			err := fmt.Errorf("EventStoreConnection '%s': operation never got response from server.\n"+
 				"UTC now: %s, operation: %s.", m.connectionName, time.Now().UTC(), o)
@@ -93,12 +95,13 @@ func (m *OperationsManager) CheckTimeoutsAndRetry(c *client.PackageConnection) e
 				removeOperations = append(removeOperations, o)
 			} else {
 				retryOperations = append(retryOperations, o)
+				retryReasons = append(retryReasons, client.RetryReason_Timeout)
 			}
 		}
 	}
 
-	for _, s := range retryOperations {
-		if err := m.ScheduleOperationRetry(s); err != nil {
+	for i, s := range retryOperations {
+		if err := m.ScheduleOperationRetry(s, retryReasons[i], nil); err != nil {
 			return err
 		}
 	}
@@ -128,13 +131,13 @@ func (m *OperationsManager) TryScheduleWaitingOperations(c *client.PackageConnec
 		panic("connection is nil")
 	}
 	m.lock.Lock()
+	defer m.lock.Unlock()
 	for len(m.waitingOperations) > 0 && len(m.activeOperations) < m.settings.MaxConcurrentItem() {
 		if err := m.ExecuteOperation(<-m.waitingOperations, c); err != nil {
 			return err
 		}
 	}
 	atomic.StoreInt32(&m.totalOperationCount, int32(len(m.activeOperations)+len(m.waitingOperations)))
-	m.lock.Unlock()
 	return nil
 }
 
@@ -147,23 +150,74 @@ func (m *OperationsManager) ExecuteOperation(o *operationItem, c *client.Package
 	if err != nil {
 		return err
 	}
+	if provider := m.settings.CredentialsProvider(); provider != nil && pkg.Flags()&client.FlagsAuthenticated == 0 {
+		credentials, err := provider.Credentials()
+		if err != nil {
+			delete(m.activeOperations, o.CorrelationId)
+			return o.operation.Fail(fmt.Errorf("Failed to get credentials: %v", err))
+		}
+		if credentials != nil {
+			pkg = client.NewTcpPackage(pkg.Command(), pkg.Flags()|client.FlagsAuthenticated, pkg.CorrelationId(),
+				pkg.Data(), credentials)
+		}
+	}
 	m.logDebug("ExecuteOperation package %s, %s, %s.", pkg.Command(), pkg.CorrelationId(), o)
-	return c.EnqueueSend(pkg)
+	if err := c.EnqueueSend(pkg); err != nil {
+		if _, isQueueFull := err.(*client.QueueFull); isQueueFull {
+			delete(m.activeOperations, o.CorrelationId)
+			return o.operation.Fail(err)
+		}
+		return err
+	}
+	return nil
 }
 
 func (m *OperationsManager) TotalOperationCount() int {
 	return int(atomic.LoadInt32(&m.totalOperationCount))
 }
 
-func (m *OperationsManager) ScheduleOperationRetry(o *operationItem) error {
+func (m *OperationsManager) ActiveOperationCount() int {
+	return len(m.activeOperations)
+}
+
+func (m *OperationsManager) WaitingOperationCount() int {
+	return len(m.waitingOperations)
+}
+
+func (m *OperationsManager) RetryPendingOperationCount() int {
+	return len(m.retryPendingOperations)
+}
+
+// result is the inspection result that requested the retry, nil for the other reasons.
+func (m *OperationsManager) ScheduleOperationRetry(
+	o *operationItem,
+	reason client.RetryReason,
+	result *client.InspectionResult,
+) error {
 	if !m.RemoveOperation(o) {
 		m.logDebug("RemoveSubscription failed when trying to retry %s", o)
 		return nil
 	}
 
-	if o.maxRetries >= 0 && o.RetryCount >= o.maxRetries {
-		m.logDebug("RETRIES LIMIT REACHED when trying to retry %s", o)
-		return o.operation.Fail(fmt.Errorf("Retries limit of %d reached for %s", o.maxRetries, o))
+	retry := &client.OperationRetry{
+		Command:    o.operation.RequestCommand(),
+		Idempotent: o.operation.IsIdempotent(),
+		Reason:     reason,
+		Decision:   client.InspectionDecision_Retry,
+		RetryCount: o.RetryCount,
+		MaxRetries: o.maxRetries,
+	}
+	if result != nil {
+		retry.Decision = result.Decision()
+		retry.Description = result.Description()
+	}
+	if !m.settings.RetryPolicy(retry.Command).ShouldRetry(retry) {
+		m.logDebug("RETRY POLICY REFUSED to retry %s (%s)", o, reason)
+		if o.maxRetries >= 0 && o.RetryCount >= o.maxRetries {
+			return o.operation.Fail(fmt.Errorf("Retries limit of %d reached for %s", o.maxRetries, o))
+		}
+		return o.operation.Fail(fmt.Errorf("Retry policy refused to retry %s after %d retries (%s)", o,
+			o.RetryCount, reason))
 	}
 
 	m.logDebug("Retrying subscription %s.", o)
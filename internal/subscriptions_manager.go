@@ -34,6 +34,18 @@ func (m *SubscriptionsManager) TryGetActiveSubscription(correlationId uuid.UUID)
 	return ok, item
 }
 
+func (m *SubscriptionsManager) ActiveSubscriptionCount() int {
+	return len(m.activeSubscriptions)
+}
+
+func (m *SubscriptionsManager) WaitingSubscriptionCount() int {
+	return len(m.waitingSubscriptions)
+}
+
+func (m *SubscriptionsManager) RetryPendingSubscriptionCount() int {
+	return len(m.retryPendingSubscriptions)
+}
+
 func (m *SubscriptionsManager) CleanUp() error {
 	err := fmt.Errorf("Connection '%s' was closed", m.connectionName)
 	for i, s := range m.activeSubscriptions {
@@ -58,6 +70,24 @@ func (m *SubscriptionsManager) CleanUp() error {
 	return nil
 }
 
+// Confirmed subscriptions stay active until the server acknowledges the unsubscription, the others are dropped.
+func (m *SubscriptionsManager) UnsubscribeAll(c *client.PackageConnection) error {
+	err := fmt.Errorf("Connection '%s' is closing", m.connectionName)
+	for i, s := range m.activeSubscriptions {
+		if s.IsSubscribed && c != nil && s.ConnectionId == c.ConnectionId() {
+			if err := s.Operation().DropSubscription(client.SubscriptionDropReason_UserInitiated, nil, c); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := s.Operation().DropSubscription(client.SubscriptionDropReason_ConnectionClosed, err, nil); err != nil {
+			return err
+		}
+		delete(m.activeSubscriptions, i)
+	}
+	return nil
+}
+
 func (m *SubscriptionsManager) PurgeSubscribedAndDroppedSubscriptions(connectionId uuid.UUID) {
 	for _, s := range m.activeSubscriptions {
 		if s.IsSubscribed && uuid.Equal(s.ConnectionId, connectionId) {
@@ -150,7 +180,10 @@ func (m *SubscriptionsManager) StartSubscription(s *SubscriptionItem, c *client.
 	m.activeSubscriptions[s.CorrelationId] = s
 
 	ok, err := s.Operation().Subscribe(s.CorrelationId, c)
-	if err != nil {
+	if _, isQueueFull := err.(*client.QueueFull); isQueueFull {
+		m.RemoveSubscription(s)
+		return s.Operation().DropSubscription(client.SubscriptionDropReason_SubscribingError, err, nil)
+	} else if err != nil {
 		return err
 	} else if !ok {
 		m.logDebug("StartSubscription REMOVING AS COULD NOT SUBSCRIBE %s.", s)
@@ -0,0 +1,206 @@
+package gesclient_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jdextraze/go-gesclient/client"
+	"github.com/jdextraze/go-gesclient/projections"
+	"github.com/satori/go.uuid"
+	"testing"
+	"time"
+)
+
+type balance struct {
+	Amount int
+}
+
+func appendEvents(t *testing.T, conn client.Connection, stream string, events ...*client.EventData) {
+	task, err := conn.AppendToStreamAsync(stream, client.ExpectedVersion_Any, events, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := task.Error(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func amountEvent(eventType string, amount int) *client.EventData {
+	data, _ := json.Marshal(map[string]int{"amount": amount})
+	return client.NewEventData(uuid.Must(uuid.NewV4()), eventType, true, data, nil)
+}
+
+func linkEvent(eventNumber int, stream string) *client.EventData {
+	data := []byte(fmt.Sprintf("%d@%s", eventNumber, stream))
+	return client.NewEventData(uuid.Must(uuid.NewV4()), "$>", false, data, nil)
+}
+
+func applyAmount(sign int) projections.EventHandler {
+	return func(state interface{}, evt *client.ResolvedEvent) (interface{}, error) {
+		data := struct{ Amount int }{}
+		if err := json.Unmarshal(evt.Event().Data(), &data); err != nil {
+			return nil, err
+		}
+		state.(*balance).Amount += sign * data.Amount
+		return state, nil
+	}
+}
+
+func balancesQuery() *projections.LocalQuery {
+	return projections.FromCategory("account").
+		Init(func() interface{} { return &balance{} }).
+		When("Deposited", applyAmount(1)).
+		When("Withdrawn", applyAmount(-1)).
+		ForeachStream()
+}
+
+func waitLive(t *testing.T, p *projections.LocalProjection) {
+	select {
+	case <-p.Live():
+	case <-p.Done():
+		t.Fatalf("Projection stopped: %v", p.Err())
+	case <-time.After(5 * time.Second):
+		t.Fatal("Projection didn't catch up in time")
+	}
+}
+
+func TestLocalProjection(t *testing.T) {
+	l := serveStreams(t)
+	defer l.Close()
+	conn := connectTo(t, l)
+	defer conn.Close()
+
+	appendEvents(t, conn, "account-1", amountEvent("Deposited", 10), amountEvent("Deposited", 5),
+		amountEvent("Withdrawn", 3))
+	appendEvents(t, conn, "account-2", amountEvent("Deposited", 7), amountEvent("Renamed", 0))
+	appendEvents(t, conn, "$ce-account", linkEvent(0, "account-1"), linkEvent(1, "account-1"),
+		linkEvent(0, "account-2"), linkEvent(2, "account-1"), linkEvent(1, "account-2"), linkEvent(5, "account-2"))
+
+	projection := projections.NewLocalProjection(conn, "balances", balancesQuery(), nil, 2, time.Minute, nil)
+	if err := projection.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	waitLive(t, projection)
+
+	expected := map[string]int{"account-1": 12, "account-2": 7}
+	for partition, amount := range expected {
+		if state, err := projection.State(partition); err != nil || state.(*balance).Amount != amount {
+			t.Errorf("State of %s doesn't match: %+v %v", partition, state, err)
+		}
+	}
+	if err := projection.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	if checkpoint := projection.Checkpoint(); checkpoint.Streams["$ce-account"] != 5 {
+		t.Errorf("Checkpoint doesn't match: %+v", checkpoint)
+	}
+
+	appendEvents(t, conn, "account-2", amountEvent("Withdrawn", 2))
+	appendEvents(t, conn, "$ce-account", linkEvent(2, "account-2"))
+
+	restarted := projections.NewLocalProjection(conn, "balances", balancesQuery(), nil, 2, time.Minute, nil)
+	if err := restarted.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer restarted.Stop()
+	waitLive(t, restarted)
+
+	expected["account-2"] = 5
+	for partition, amount := range expected {
+		if state, err := restarted.State(partition); err != nil || state.(*balance).Amount != amount {
+			t.Errorf("Restored state of %s doesn't match: %+v %v", partition, state, err)
+		}
+	}
+}
+
+func TestLocalProjection_FromAll(t *testing.T) {
+	l := serveStreams(t)
+	defer l.Close()
+	conn := connectTo(t, l)
+	defer conn.Close()
+
+	appendEvents(t, conn, "account-1", amountEvent("Deposited", 10))
+	appendEvents(t, conn, "$ce-account", linkEvent(0, "account-1"))
+	appendEvents(t, conn, "account-2", amountEvent("Deposited", 7))
+
+	var seen []string
+	query := func() *projections.LocalQuery {
+		return projections.FromAll().
+			Init(func() interface{} { return &balance{} }).
+			When("Deposited", applyAmount(1)).
+			WhenAny(func(state interface{}, evt *client.ResolvedEvent) (interface{}, error) {
+				seen = append(seen, evt.Event().EventStreamId())
+				return state, nil
+			}).
+			ForeachStream()
+	}
+	checkpoints := func() int {
+		task, err := conn.ReadStreamEventsForwardAsync("balances-checkpoint", 0, 100, false, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(task.Result().(*client.StreamEventsSlice).Events())
+	}
+
+	// The checkpoints written to $all by the default store must not be processed, or they would be checkpointed again
+	for i := 0; i < 2; i++ {
+		projection := projections.NewLocalProjection(conn, "balances", query(), nil, 100, 10*time.Millisecond, nil)
+		if err := projection.Start(); err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+		waitLive(t, projection)
+		time.Sleep(50 * time.Millisecond)
+		if err := projection.Stop(); err != nil {
+			t.Fatalf("Stop failed: %v", err)
+		}
+
+		for partition, amount := range map[string]int{"account-1": 10, "account-2": 7} {
+			if state, err := projection.State(partition); err != nil || state.(*balance).Amount != amount {
+				t.Errorf("State of %s doesn't match: %+v %v", partition, state, err)
+			}
+		}
+		if count := checkpoints(); count != 1 {
+			t.Errorf("Checkpoints count doesn't match after run %d: %d != 1", i, count)
+		}
+	}
+	if len(seen) != 0 {
+		t.Errorf("Only the events without handler should be seen by WhenAny: %v", seen)
+	}
+}
+
+func TestLocalProjection_Faulted(t *testing.T) {
+	l := serveStreams(t)
+	defer l.Close()
+	conn := connectTo(t, l)
+	defer conn.Close()
+
+	appendEvents(t, conn, "orders", amountEvent("Placed", 1), amountEvent("Placed", 2))
+
+	failure := errors.New("handler failed")
+	count := 0
+	query := projections.FromStreams("orders").
+		Init(func() interface{} { return 0 }).
+		WhenAny(func(state interface{}, evt *client.ResolvedEvent) (interface{}, error) {
+			if count++; count == 2 {
+				return nil, failure
+			}
+			return state.(int) + 1, nil
+		})
+	projection := projections.NewLocalProjection(conn, "orders", query, projections.NewMemoryStateStore(), 10,
+		time.Minute, nil)
+	if err := projection.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	select {
+	case <-projection.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Projection didn't fault in time")
+	}
+	if err := projection.Err(); err != failure {
+		t.Errorf("Expected the handler error: %v", err)
+	}
+	if err := projection.Stop(); err != failure {
+		t.Errorf("Stop should return the handler error: %v", err)
+	}
+}
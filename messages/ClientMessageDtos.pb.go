@@ -810,6 +810,7 @@ type WriteEventsCompleted struct {
 	LastEventNumber  *int32           `protobuf:"varint,4,req,name=last_event_number" json:"last_event_number,omitempty"`
 	PreparePosition  *int64           `protobuf:"varint,5,opt,name=prepare_position" json:"prepare_position,omitempty"`
 	CommitPosition   *int64           `protobuf:"varint,6,opt,name=commit_position" json:"commit_position,omitempty"`
+	CurrentVersion   *int32           `protobuf:"varint,7,opt,name=current_version" json:"current_version,omitempty"`
 	XXX_unrecognized []byte           `json:"-"`
 }
 
@@ -860,6 +861,13 @@ func (m *WriteEventsCompleted) GetCommitPosition() int64 {
 	return 0
 }
 
+func (m *WriteEventsCompleted) GetCurrentVersion() int32 {
+	if m != nil && m.CurrentVersion != nil {
+		return *m.CurrentVersion
+	}
+	return 0
+}
+
 type DeleteStream struct {
 	EventStreamId    *string `protobuf:"bytes,1,req,name=event_stream_id" json:"event_stream_id,omitempty"`
 	ExpectedVersion  *int32  `protobuf:"varint,2,req,name=expected_version" json:"expected_version,omitempty"`
@@ -2303,133 +2311,134 @@ func init() {
 }
 
 var fileDescriptor0 = []byte{
-	// 2038 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x59, 0x5b, 0x6f, 0xe4, 0x48,
-	0xf5, 0x1f, 0xbb, 0x2f, 0xe9, 0x3e, 0xe9, 0x74, 0xdc, 0xce, 0xad, 0x3b, 0x9b, 0xfc, 0x27, 0x7f,
-	0xef, 0x0e, 0x64, 0x11, 0x9b, 0x91, 0x02, 0x3c, 0x30, 0x5a, 0x1e, 0x32, 0xc9, 0x04, 0x06, 0x31,
-	0x61, 0x94, 0x64, 0x18, 0x84, 0x84, 0x4c, 0xc5, 0x3e, 0xdd, 0xf1, 0xc6, 0xed, 0x32, 0x55, 0xd5,
-	0xb9, 0xac, 0x78, 0x65, 0x91, 0xb8, 0x7c, 0x17, 0x3e, 0x00, 0xef, 0x7c, 0x02, 0x5e, 0x40, 0xe2,
-	0x69, 0x3f, 0x04, 0x48, 0x08, 0xa1, 0xaa, 0x72, 0xb7, 0xdd, 0x6d, 0x3b, 0xc9, 0x5c, 0xf6, 0x05,
-	0xf1, 0xd6, 0xae, 0x53, 0x75, 0xae, 0xbf, 0x3a, 0x97, 0x6a, 0x78, 0x3a, 0x08, 0xc4, 0xf9, 0xe8,
-	0x6c, 0xc7, 0xa3, 0xc3, 0xc7, 0x9f, 0xf9, 0x78, 0x2d, 0x18, 0xf9, 0x1c, 0x1f, 0x0f, 0xe8, 0x27,
-	0x03, 0xe4, 0x5e, 0x18, 0x60, 0x24, 0x1e, 0x0f, 0x91, 0x73, 0x32, 0x40, 0xfe, 0x78, 0x5f, 0x7d,
-	0xbf, 0xd0, 0x9f, 0x07, 0x82, 0xf2, 0x9d, 0x98, 0x51, 0x41, 0xed, 0xc6, 0x78, 0x87, 0xf3, 0x5b,
-	0x03, 0x1a, 0x47, 0x78, 0xf5, 0xec, 0x12, 0x23, 0x61, 0x5b, 0xd0, 0x40, 0xf9, 0xc3, 0x0d, 0xfc,
-	0xae, 0xb1, 0x65, 0x6e, 0xb7, 0x6c, 0x1b, 0x40, 0xaf, 0x88, 0x9b, 0x18, 0xbb, 0xe6, 0x96, 0xb9,
-	0xdd, 0xb4, 0x7b, 0xd0, 0xf1, 0x89, 0x20, 0xae, 0x47, 0x23, 0x31, 0x21, 0x55, 0xb6, 0xcc, 0xed,
-	0x9a, 0xbd, 0x09, 0x2b, 0x43, 0x14, 0x24, 0x4f, 0xae, 0x2a, 0x72, 0x0b, 0xaa, 0x92, 0xd4, 0xad,
-	0x29, 0xde, 0x16, 0x34, 0xc6, 0x9b, 0xbb, 0xf5, 0x2d, 0x63, 0xbb, 0xe5, 0x7c, 0x69, 0xc0, 0xbc,
-	0xd2, 0xe4, 0x18, 0x3d, 0xca, 0x7c, 0x7b, 0x0d, 0x16, 0xb5, 0x74, 0x2e, 0x18, 0x92, 0xe1, 0x58,
-	0xad, 0xa6, 0xbd, 0x0c, 0x2d, 0x4d, 0x88, 0x46, 0xc3, 0x33, 0x64, 0x4a, 0xb1, 0xda, 0x94, 0xfa,
-	0x95, 0x02, 0xf5, 0xab, 0xe5, 0xea, 0xd7, 0x6e, 0x57, 0xbf, 0x3e, 0xa5, 0xfe, 0x5c, 0x4e, 0xfd,
-	0x86, 0x54, 0xdf, 0x5e, 0x84, 0x39, 0x8f, 0x21, 0x11, 0xe8, 0x77, 0x9b, 0x5b, 0xc6, 0x76, 0xc5,
-	0x5e, 0x81, 0x85, 0x64, 0xc1, 0xc5, 0x98, 0x7a, 0xe7, 0x5d, 0x90, 0xcb, 0x0e, 0x81, 0xe5, 0x63,
-	0xe4, 0x34, 0xbc, 0x44, 0xff, 0x79, 0xe4, 0xe3, 0x35, 0xfa, 0xda, 0xfd, 0x1f, 0x41, 0x4d, 0x69,
-	0xdb, 0x35, 0xb6, 0x8c, 0xed, 0xf9, 0xdd, 0x95, 0x9d, 0x71, 0x94, 0x76, 0xb2, 0x4e, 0xf9, 0x10,
-	0xaa, 0x61, 0x10, 0x5d, 0x74, 0xcd, 0x5b, 0x36, 0x39, 0x7f, 0x30, 0x60, 0x61, 0x2c, 0x23, 0xc7,
-	0xdc, 0x7c, 0x37, 0xe6, 0x32, 0x2c, 0x1e, 0x1d, 0x0e, 0x03, 0xe1, 0xc6, 0x94, 0x07, 0x22, 0xa0,
-	0x91, 0x72, 0x77, 0xc5, 0xee, 0x82, 0x15, 0x33, 0x8c, 0x09, 0xc3, 0x94, 0x22, 0x9d, 0x5e, 0x71,
-	0x7e, 0x05, 0xf3, 0xaf, 0x59, 0x20, 0x50, 0xb1, 0xe1, 0xe5, 0x81, 0xed, 0x82, 0x85, 0xd7, 0x31,
-	0x7a, 0xd2, 0x65, 0x97, 0xc8, 0xb8, 0xe4, 0xa0, 0x83, 0xeb, 0x40, 0x5d, 0x1d, 0xe1, 0xdd, 0xca,
-	0x56, 0x65, 0x7b, 0x7e, 0xd7, 0x4e, 0x75, 0x9b, 0xe0, 0x77, 0x15, 0xda, 0x0c, 0x7f, 0x39, 0x0a,
-	0x18, 0xba, 0x43, 0xc2, 0x05, 0x32, 0x25, 0xbd, 0xe1, 0xfc, 0xc9, 0x80, 0xe5, 0x8c, 0xf8, 0x7d,
-	0x3a, 0x8c, 0x43, 0x14, 0xe8, 0xdb, 0x1f, 0x43, 0x9d, 0x21, 0x1f, 0x85, 0xda, 0x2b, 0xed, 0xdd,
-	0x5e, 0xca, 0xf4, 0xc7, 0x31, 0x32, 0x22, 0x2d, 0x38, 0x56, 0x1b, 0x64, 0x70, 0x13, 0x9a, 0x72,
-	0x4e, 0xd3, 0x5e, 0x07, 0xbb, 0x1f, 0x30, 0x2e, 0xdc, 0x29, 0x24, 0xea, 0x7b, 0xd0, 0x83, 0x4e,
-	0x48, 0x66, 0x49, 0xfa, 0x0e, 0x14, 0xf9, 0xa8, 0xa6, 0xd0, 0x52, 0xe0, 0xd6, 0xba, 0xc2, 0x4b,
-	0x04, 0xad, 0x03, 0x94, 0x0a, 0x9f, 0x28, 0x6f, 0xbd, 0x8d, 0xf7, 0xf2, 0x9e, 0x91, 0x8a, 0x36,
-	0xec, 0x25, 0x98, 0x3f, 0x27, 0xcc, 0x77, 0x7d, 0xc5, 0xbf, 0x5b, 0xdd, 0x32, 0xb6, 0x1b, 0xce,
-	0x17, 0x06, 0xac, 0x64, 0x05, 0xbe, 0x1f, 0x7f, 0x15, 0x19, 0x5e, 0x29, 0x33, 0xbc, 0xaa, 0x0c,
-	0xff, 0x39, 0x58, 0xa7, 0x8c, 0x44, 0x9c, 0x78, 0x72, 0xf1, 0x44, 0x10, 0x26, 0xde, 0xa3, 0xf1,
-	0x0e, 0x85, 0xde, 0x2c, 0xfb, 0xd4, 0xd4, 0x55, 0x68, 0x8b, 0x94, 0x38, 0x16, 0x53, 0xc9, 0xb8,
-	0xc0, 0x7c, 0x03, 0x17, 0x48, 0x43, 0x9b, 0x4e, 0x7f, 0xca, 0x1e, 0x85, 0xc8, 0x52, 0x39, 0x29,
-	0xde, 0xcd, 0x37, 0xc0, 0x7b, 0x91, 0x61, 0x4a, 0xce, 0x57, 0x6b, 0xd8, 0x3e, 0x74, 0x32, 0x02,
-	0xf7, 0x55, 0x30, 0x4b, 0x05, 0xe5, 0xb5, 0x36, 0x95, 0xd6, 0x7f, 0x35, 0x60, 0x3d, 0xc7, 0xe5,
-	0x2b, 0xd5, 0xbb, 0xe4, 0x0e, 0x57, 0xcb, 0xef, 0x70, 0xad, 0xf4, 0x0e, 0xd7, 0xcb, 0xa0, 0x3c,
-	0xa7, 0xa0, 0x1c, 0x42, 0xf3, 0x18, 0x49, 0x92, 0x8b, 0xdf, 0xb0, 0xae, 0x75, 0xc1, 0x62, 0x3a,
-	0x97, 0xbb, 0x32, 0x39, 0xbb, 0x82, 0xf2, 0xe4, 0xfa, 0x96, 0x25, 0xbc, 0x7f, 0x19, 0x60, 0x4f,
-	0xc4, 0xa5, 0x2e, 0xfc, 0x74, 0xe6, 0xfa, 0x7e, 0x33, 0x75, 0x55, 0x7e, 0x77, 0xba, 0x94, 0x78,
-	0xef, 0x93, 0x71, 0x05, 0x31, 0x55, 0x05, 0xf9, 0xbf, 0xec, 0xe1, 0x82, 0x6a, 0xb6, 0x00, 0x35,
-	0x64, 0x8c, 0xb2, 0x04, 0x22, 0x9f, 0xc1, 0xe2, 0x2c, 0xc3, 0x79, 0x98, 0x3b, 0x19, 0x79, 0x1e,
-	0x72, 0x6e, 0x3d, 0xb0, 0x5b, 0xd0, 0x38, 0xa2, 0xe2, 0x90, 0x8e, 0x22, 0xdf, 0x32, 0xf4, 0x97,
-	0xce, 0x3e, 0x96, 0x69, 0x77, 0x60, 0x41, 0xff, 0xd6, 0x59, 0xc9, 0xb7, 0x2a, 0x76, 0x13, 0x6a,
-	0xcf, 0x24, 0x77, 0xab, 0x6a, 0x5b, 0xd0, 0xda, 0x53, 0x5c, 0x0e, 0x30, 0x0a, 0xd0, 0xb7, 0x6a,
-	0xce, 0xef, 0x0c, 0xb0, 0xa4, 0x30, 0x7d, 0xe8, 0xae, 0x9a, 0xd3, 0x83, 0x4e, 0x9f, 0xd1, 0xa1,
-	0x5b, 0xe0, 0xf9, 0x0e, 0x34, 0x87, 0xe4, 0xda, 0xf5, 0xe8, 0x28, 0x12, 0x49, 0x6a, 0x2f, 0x0a,
-	0x46, 0xb5, 0x24, 0x18, 0x35, 0x15, 0x8c, 0x7f, 0x9b, 0xd0, 0x9b, 0xd5, 0x26, 0x8d, 0xc9, 0xce,
-	0xe4, 0x9e, 0x1b, 0xea, 0x9e, 0xdf, 0xe5, 0xd6, 0x83, 0x19, 0xb8, 0x7f, 0x7b, 0x3a, 0x86, 0x85,
-	0x42, 0x32, 0x94, 0xc4, 0xf5, 0x3d, 0xe8, 0x44, 0x78, 0xfd, 0x16, 0xb5, 0x2b, 0xe0, 0x2e, 0x46,
-	0xbe, 0x4b, 0xfb, 0x89, 0x1b, 0xb5, 0x8d, 0xf6, 0x06, 0x2c, 0xab, 0x43, 0xf9, 0x02, 0x26, 0xaf,
-	0xe8, 0x04, 0x0a, 0x73, 0x0a, 0x0a, 0x51, 0x36, 0x3a, 0xa5, 0x58, 0x48, 0xa2, 0x6f, 0xe4, 0xa3,
-	0x6f, 0xda, 0x8b, 0x30, 0x7f, 0x44, 0xc5, 0x0b, 0xea, 0x07, 0xfd, 0xe0, 0x6e, 0x38, 0xfc, 0x46,
-	0x35, 0x43, 0xc4, 0xdf, 0x0b, 0xc3, 0x14, 0x0b, 0xb3, 0x9a, 0x1a, 0xa5, 0x1d, 0x8c, 0xa9, 0x28,
-	0xef, 0x05, 0x0a, 0x7f, 0x31, 0x61, 0x75, 0x4a, 0x93, 0x14, 0x07, 0x6f, 0xa1, 0xd2, 0xd7, 0x67,
-	0x5a, 0xa2, 0xb5, 0x3c, 0x74, 0x34, 0x66, 0x36, 0x60, 0x59, 0x45, 0x3b, 0x5f, 0x65, 0x25, 0x9b,
-	0x4d, 0x58, 0x51, 0xd4, 0x82, 0xb6, 0x44, 0x92, 0xbf, 0x3f, 0x01, 0x9c, 0x4c, 0x71, 0xed, 0xdd,
-	0x9d, 0x69, 0xc0, 0xe5, 0x4d, 0x19, 0x2f, 0xeb, 0xc8, 0x3e, 0x19, 0xc7, 0x75, 0x16, 0x05, 0x3f,
-	0x9c, 0x04, 0xa5, 0x08, 0x02, 0x33, 0x11, 0x36, 0xd2, 0x08, 0x9b, 0xb9, 0x08, 0x57, 0x9c, 0xbf,
-	0x57, 0x60, 0x63, 0x5f, 0x75, 0xda, 0x2f, 0x65, 0xe5, 0xe7, 0xb2, 0x73, 0x3f, 0x19, 0x9d, 0x71,
-	0x8f, 0x05, 0xb1, 0x34, 0xc5, 0x7e, 0x08, 0x6b, 0x3c, 0xf3, 0xed, 0x0e, 0x18, 0x1d, 0xc5, 0x6e,
-	0x44, 0x86, 0x98, 0x24, 0x81, 0x82, 0xec, 0x60, 0x8e, 0xdb, 0x8a, 0x92, 0xe4, 0x6b, 0x03, 0x70,
-	0xd9, 0x33, 0xb8, 0x32, 0x7b, 0x24, 0x37, 0xe4, 0x23, 0xd8, 0x48, 0xbc, 0xe3, 0x8a, 0x60, 0x88,
-	0x74, 0x24, 0xdc, 0x61, 0x10, 0x86, 0x01, 0x47, 0x8f, 0x46, 0x3e, 0x4f, 0xea, 0x47, 0x0f, 0x3a,
-	0x4c, 0xb5, 0xd2, 0x2e, 0x17, 0x44, 0x04, 0x5c, 0x04, 0x1e, 0x57, 0x57, 0xa5, 0x21, 0xc5, 0x85,
-	0xc1, 0x25, 0xba, 0x67, 0xa3, 0x7e, 0x1f, 0x99, 0xcb, 0x83, 0xcf, 0x51, 0xcd, 0x1b, 0x35, 0xa9,
-	0x21, 0x43, 0xe2, 0xbb, 0x67, 0x44, 0x78, 0xe7, 0x9a, 0xd0, 0x50, 0x84, 0x25, 0x98, 0xcf, 0xee,
-	0x6e, 0x8e, 0x77, 0x4b, 0xb8, 0x32, 0x14, 0xec, 0x26, 0x01, 0x2d, 0x28, 0xc2, 0x3a, 0xd8, 0x31,
-	0x43, 0xb9, 0x9b, 0xc9, 0x5c, 0xeb, 0x32, 0x7a, 0x16, 0x44, 0xdd, 0x79, 0x25, 0x7c, 0x13, 0x56,
-	0xbc, 0x73, 0xf4, 0x2e, 0x62, 0x1a, 0x44, 0xc2, 0x25, 0x7d, 0x81, 0x4c, 0x99, 0xd1, 0x6d, 0xa9,
-	0xa3, 0x1b, 0xb0, 0x9c, 0x21, 0xa7, 0xb7, 0x61, 0xa1, 0x88, 0x1a, 0x44, 0x09, 0xb5, 0x3d, 0xa6,
-	0x26, 0x01, 0x38, 0x43, 0x96, 0x39, 0xbb, 0xa8, 0xa8, 0x0f, 0x61, 0x4d, 0xc6, 0xc2, 0x97, 0x53,
-	0x17, 0x1f, 0x0d, 0xa5, 0x29, 0x82, 0x11, 0x81, 0x83, 0x9b, 0xae, 0xa5, 0xc0, 0xf2, 0x53, 0xd8,
-	0xd0, 0xb7, 0xff, 0x7d, 0xc7, 0x57, 0x41, 0xe7, 0x55, 0xec, 0xff, 0x0f, 0x3a, 0xff, 0xad, 0xd0,
-	0xf9, 0xb5, 0x09, 0x8f, 0x6e, 0x0b, 0x70, 0x9a, 0x82, 0x2f, 0x66, 0xda, 0xa3, 0xe3, 0x34, 0xd3,
-	0xdd, 0x8b, 0xc1, 0xad, 0xbb, 0x66, 0xb3, 0x61, 0x5b, 0x0a, 0x23, 0x5c, 0x25, 0x73, 0xa9, 0xa6,
-	0x0b, 0xce, 0xdd, 0xc7, 0xa7, 0x73, 0xa4, 0x05, 0xad, 0x03, 0x8a, 0xfc, 0x88, 0x8a, 0x67, 0xd7,
-	0x01, 0x17, 0x96, 0x61, 0x37, 0xa0, 0x7a, 0x48, 0x82, 0xb0, 0x30, 0x47, 0x7e, 0x61, 0xc2, 0xa3,
-	0xdb, 0x72, 0xe4, 0xbd, 0xfc, 0x70, 0x2f, 0x06, 0xb7, 0xee, 0xba, 0xcb, 0x0f, 0xbf, 0x00, 0xe7,
-	0xee, 0xe3, 0xd3, 0x7e, 0xe8, 0xc0, 0xc2, 0x5e, 0x28, 0x01, 0x7e, 0xa3, 0xfc, 0xc0, 0xef, 0x70,
-	0x84, 0x04, 0xc4, 0x6d, 0xc9, 0xe4, 0x5e, 0x8e, 0xb8, 0x17, 0x83, 0x5b, 0x77, 0xdd, 0x03, 0x10,
-	0x77, 0x1f, 0x7f, 0x17, 0x40, 0x5c, 0xc1, 0xc3, 0x7d, 0x1a, 0x45, 0xe8, 0x89, 0x53, 0x5a, 0x92,
-	0xfb, 0xd6, 0x60, 0x71, 0x2a, 0xf7, 0x4d, 0x7a, 0xe6, 0xd2, 0x9c, 0xe7, 0xc0, 0x3a, 0x09, 0x43,
-	0x7a, 0x85, 0xbe, 0x1b, 0x44, 0x6e, 0x3f, 0x0c, 0x06, 0xe7, 0xc2, 0x1d, 0x7b, 0x4d, 0xf7, 0x4d,
-	0xce, 0x6b, 0x78, 0x58, 0x2c, 0x6f, 0xcf, 0xbb, 0x48, 0x1b, 0xb4, 0x62, 0xc1, 0x1f, 0xc0, 0x52,
-	0xcc, 0xa8, 0xb4, 0x03, 0x7d, 0x77, 0xfc, 0xda, 0xa7, 0x67, 0xe4, 0x96, 0xf3, 0x4f, 0xa3, 0x8c,
-	0xf3, 0x11, 0x79, 0x27, 0xce, 0xf9, 0xc9, 0xf1, 0x47, 0x50, 0xd7, 0x83, 0xa8, 0xca, 0xde, 0xed,
-	0xdd, 0x6f, 0xa5, 0xd0, 0xb8, 0x43, 0x83, 0x9d, 0x23, 0x72, 0xb1, 0xa7, 0x8e, 0x3e, 0x99, 0x7b,
-	0x15, 0x5d, 0x44, 0xf4, 0x2a, 0x72, 0xf6, 0xa0, 0x39, 0x59, 0x95, 0x21, 0x4d, 0xd6, 0xad, 0x07,
-	0x32, 0x80, 0x2f, 0x09, 0xbb, 0xd0, 0x0d, 0xd0, 0xb1, 0x4c, 0xc9, 0x96, 0x29, 0x17, 0x4f, 0x2e,
-	0x82, 0xd8, 0xaa, 0xa8, 0x5f, 0x82, 0xc6, 0x56, 0xd5, 0xb9, 0x04, 0xa7, 0x0c, 0x8d, 0x51, 0x3f,
-	0x60, 0x43, 0x35, 0x07, 0x97, 0xf6, 0xe9, 0xba, 0xd5, 0x2c, 0xf0, 0xcd, 0xe4, 0xc9, 0x37, 0x3f,
-	0x13, 0x48, 0x47, 0xd4, 0x9c, 0x9f, 0xc1, 0xc7, 0xc5, 0x72, 0x33, 0x93, 0xc8, 0x5e, 0x1c, 0x23,
-	0x61, 0xe8, 0xa7, 0x23, 0xa4, 0x71, 0x9f, 0x11, 0xd2, 0x39, 0x84, 0xce, 0xc9, 0x38, 0xf3, 0x9f,
-	0xd2, 0x7b, 0xbc, 0x7e, 0xe5, 0xca, 0xad, 0x7e, 0x59, 0x38, 0x81, 0xee, 0x5b, 0x7a, 0xa4, 0xd0,
-	0x70, 0x53, 0x19, 0xfe, 0x3d, 0x58, 0x2a, 0x32, 0xf1, 0x6b, 0xd3, 0x26, 0x96, 0xf5, 0xe4, 0xce,
-	0x1a, 0xac, 0xbc, 0x8a, 0x26, 0x75, 0xed, 0x90, 0xd1, 0xa1, 0xe6, 0xe6, 0xfc, 0xc3, 0x80, 0xa5,
-	0xac, 0xb6, 0x07, 0x8c, 0xc6, 0x31, 0xfa, 0xf6, 0xf1, 0x24, 0x3f, 0x18, 0xaa, 0x0f, 0xcf, 0x0c,
-	0x7e, 0x05, 0xdb, 0x73, 0x6b, 0xc7, 0xea, 0xec, 0x93, 0x56, 0x46, 0xa8, 0xef, 0xfc, 0xde, 0x80,
-	0xd5, 0xe2, 0x8d, 0x32, 0x5f, 0x64, 0xb7, 0xea, 0xec, 0x32, 0x95, 0x41, 0x8c, 0xa9, 0x99, 0xdd,
-	0xb4, 0xff, 0x1f, 0x36, 0x8b, 0x91, 0x90, 0x4e, 0xed, 0x9b, 0xd0, 0x9b, 0x04, 0x94, 0xbd, 0x20,
-	0xd7, 0xfb, 0xb2, 0x90, 0x1f, 0x23, 0xf1, 0xce, 0xd1, 0xb7, 0xaa, 0xce, 0x97, 0x26, 0xc0, 0x11,
-	0x15, 0x3f, 0x20, 0x91, 0x1f, 0xa2, 0x6f, 0x7f, 0x27, 0x63, 0xb1, 0xbc, 0x63, 0x8f, 0x32, 0x4f,
-	0x60, 0x93, 0x5d, 0x99, 0x9f, 0x89, 0xe6, 0x6b, 0xb0, 0x48, 0x7c, 0x5f, 0x45, 0x91, 0x84, 0x6e,
-	0x10, 0xf5, 0xa9, 0x8a, 0x58, 0x6b, 0xfd, 0xcf, 0x06, 0xc0, 0x0b, 0x35, 0x8e, 0x3d, 0x8f, 0xfa,
-	0x54, 0x46, 0x1e, 0xaf, 0x05, 0x32, 0xb9, 0x4b, 0x78, 0xb1, 0x4b, 0x7c, 0x9f, 0x21, 0xe7, 0xe9,
-	0xab, 0xc0, 0x14, 0x35, 0xa6, 0x4c, 0x24, 0xaf, 0x02, 0x9b, 0xb0, 0x32, 0x21, 0x9d, 0x0b, 0x91,
-	0x9e, 0xac, 0xa8, 0x93, 0xeb, 0x60, 0x4f, 0x93, 0xd5, 0x51, 0xdd, 0xe4, 0x7d, 0x08, 0x1f, 0x4c,
-	0x68, 0x1c, 0xbd, 0x11, 0xc3, 0x29, 0xd1, 0x35, 0x95, 0x5b, 0xb6, 0xa0, 0x5b, 0xb4, 0x49, 0xb1,
-	0xa9, 0x2b, 0xec, 0x7d, 0x0a, 0x56, 0xce, 0x6c, 0x1d, 0x0c, 0x39, 0x52, 0xdd, 0x58, 0x0f, 0x64,
-	0x12, 0x39, 0xa5, 0xf4, 0xe9, 0x88, 0xdf, 0x58, 0x86, 0xbd, 0x00, 0x4d, 0x39, 0x4c, 0x29, 0xd3,
-	0x2d, 0xd3, 0xb1, 0xc1, 0x3a, 0xf1, 0xc8, 0x25, 0x46, 0x03, 0x3c, 0x20, 0x82, 0x9c, 0x11, 0x8e,
-	0xce, 0xdf, 0x0c, 0xe8, 0xcd, 0x2e, 0xa6, 0x85, 0xf0, 0xe9, 0x4c, 0x21, 0xdc, 0xcd, 0x60, 0xaf,
-	0xec, 0xd0, 0x84, 0x92, 0x54, 0xaa, 0xc9, 0xf8, 0xa7, 0x9f, 0x83, 0x57, 0x60, 0x41, 0x50, 0x21,
-	0x9d, 0x1b, 0x0c, 0xd1, 0x1d, 0xf2, 0xf4, 0xf5, 0x41, 0x2f, 0xf3, 0x98, 0x78, 0xe8, 0x72, 0x72,
-	0x89, 0x7e, 0xf2, 0x1f, 0xc2, 0x77, 0xa1, 0x3d, 0xc3, 0x72, 0xaa, 0xf8, 0xb5, 0x01, 0x9e, 0x47,
-	0x2f, 0x19, 0x1d, 0x48, 0x4f, 0x5a, 0x86, 0x0d, 0x50, 0x97, 0xa5, 0x0f, 0x7d, 0xcb, 0xfc, 0xc6,
-	0x1f, 0x0d, 0x58, 0x9c, 0x7d, 0x0c, 0x9c, 0x3a, 0x6c, 0x43, 0xfb, 0xa5, 0x1e, 0x7f, 0x4f, 0x75,
-	0xef, 0xad, 0xdf, 0x1d, 0xf4, 0x1b, 0xe4, 0x78, 0xc9, 0x94, 0xdb, 0x0e, 0x29, 0xbb, 0x22, 0xcc,
-	0x1f, 0xaf, 0xc9, 0xf9, 0x7c, 0xf9, 0x35, 0xa3, 0xd1, 0xe0, 0x59, 0xf2, 0xf8, 0xfc, 0x13, 0xfd,
-	0xf6, 0x6c, 0x55, 0xf3, 0x0f, 0x17, 0x35, 0x7b, 0x15, 0xec, 0xe7, 0xd1, 0x25, 0x09, 0x03, 0x3f,
-	0xf3, 0xd2, 0x69, 0xd5, 0x73, 0x77, 0x6b, 0xee, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x4b, 0x31,
-	0x2b, 0xba, 0xe7, 0x1b, 0x00, 0x00,
+	// 2054 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x59, 0xdd, 0x6f, 0xe4, 0x48,
+	0x11, 0x5f, 0x7b, 0x3e, 0x32, 0x53, 0x99, 0x4c, 0x3c, 0x4e, 0xb2, 0x99, 0xc9, 0x25, 0xec, 0xe0,
+	0xbb, 0xe5, 0x72, 0x88, 0xcb, 0x4a, 0x01, 0x1e, 0x58, 0x1d, 0x0f, 0xd9, 0x64, 0x03, 0x8b, 0xd8,
+	0xb0, 0x9a, 0x64, 0x59, 0x84, 0x84, 0x4c, 0xc7, 0xae, 0x99, 0xf8, 0xe2, 0x71, 0x9b, 0xee, 0x9e,
+	0x7c, 0x9c, 0x78, 0xe5, 0x90, 0xf8, 0xf8, 0x5f, 0xf8, 0x2f, 0xf8, 0x0b, 0x78, 0x01, 0x89, 0xa7,
+	0xe3, 0x7f, 0x00, 0x09, 0x21, 0xd4, 0x6d, 0xcf, 0xd8, 0x1e, 0xdb, 0x49, 0xf6, 0xeb, 0x05, 0xf1,
+	0x36, 0xee, 0xaa, 0xae, 0x8f, 0x5f, 0x55, 0x57, 0x77, 0xd5, 0xc0, 0x93, 0x91, 0x27, 0xce, 0x26,
+	0xa7, 0x3b, 0x0e, 0x1d, 0x3f, 0xfa, 0xdc, 0xc5, 0x2b, 0xc1, 0xc8, 0x17, 0xf8, 0x68, 0x44, 0x3f,
+	0x1d, 0x21, 0x77, 0x7c, 0x0f, 0x03, 0xf1, 0x68, 0x8c, 0x9c, 0x93, 0x11, 0xf2, 0x47, 0xfb, 0xea,
+	0xfb, 0x79, 0xf4, 0x79, 0x20, 0x28, 0xdf, 0x09, 0x19, 0x15, 0xd4, 0x6c, 0x4c, 0x39, 0xac, 0xdf,
+	0x69, 0xd0, 0x38, 0xc2, 0xcb, 0xa7, 0x17, 0x18, 0x08, 0xd3, 0x80, 0x06, 0xca, 0x1f, 0xb6, 0xe7,
+	0x76, 0xb5, 0xbe, 0xbe, 0xdd, 0x32, 0x4d, 0x80, 0x68, 0x45, 0x5c, 0x87, 0xd8, 0xd5, 0xfb, 0xfa,
+	0x76, 0xd3, 0xec, 0x41, 0xc7, 0x25, 0x82, 0xd8, 0x0e, 0x0d, 0xc4, 0x8c, 0x54, 0xe9, 0xeb, 0xdb,
+	0x35, 0x73, 0x0b, 0xd6, 0xc6, 0x28, 0x48, 0x9e, 0x5c, 0x55, 0xe4, 0x16, 0x54, 0x25, 0xa9, 0x5b,
+	0x53, 0xb2, 0x0d, 0x68, 0x4c, 0x99, 0xbb, 0xf5, 0xbe, 0xb6, 0xdd, 0xb2, 0xbe, 0xd2, 0x60, 0x51,
+	0x59, 0x32, 0x40, 0x87, 0x32, 0xd7, 0x5c, 0x87, 0xe5, 0x48, 0x3b, 0x17, 0x0c, 0xc9, 0x78, 0x6a,
+	0x56, 0xd3, 0x5c, 0x85, 0x56, 0x44, 0x08, 0x26, 0xe3, 0x53, 0x64, 0xca, 0xb0, 0x5a, 0xc6, 0xfc,
+	0x4a, 0x81, 0xf9, 0xd5, 0x72, 0xf3, 0x6b, 0x37, 0x9b, 0x5f, 0xcf, 0x98, 0xbf, 0x90, 0x33, 0xbf,
+	0x21, 0xcd, 0x37, 0x97, 0x61, 0xc1, 0x61, 0x48, 0x04, 0xba, 0xdd, 0x66, 0x5f, 0xdb, 0xae, 0x98,
+	0x6b, 0xb0, 0x14, 0x2f, 0xd8, 0x18, 0x52, 0xe7, 0xac, 0x0b, 0x72, 0xd9, 0x22, 0xb0, 0x3a, 0x40,
+	0x4e, 0xfd, 0x0b, 0x74, 0x9f, 0x05, 0x2e, 0x5e, 0xa1, 0x1b, 0xc1, 0xff, 0x11, 0xd4, 0x94, 0xb5,
+	0x5d, 0xad, 0xaf, 0x6d, 0x2f, 0xee, 0xae, 0xed, 0x4c, 0xa3, 0xb4, 0x93, 0x06, 0xe5, 0x43, 0xa8,
+	0xfa, 0x5e, 0x70, 0xde, 0xd5, 0x6f, 0x60, 0xb2, 0xfe, 0xa8, 0xc1, 0xd2, 0x54, 0x47, 0x4e, 0xb8,
+	0xfe, 0x76, 0xc2, 0x65, 0x58, 0x1c, 0x3a, 0x1e, 0x7b, 0xc2, 0x0e, 0x29, 0xf7, 0x84, 0x47, 0x03,
+	0x05, 0x77, 0xc5, 0xec, 0x82, 0x11, 0x32, 0x0c, 0x09, 0xc3, 0x84, 0x22, 0x41, 0xaf, 0x58, 0xbf,
+	0x86, 0xc5, 0x57, 0xcc, 0x13, 0xa8, 0xc4, 0xf0, 0xf2, 0xc0, 0x76, 0xc1, 0xc0, 0xab, 0x10, 0x1d,
+	0x09, 0xd9, 0x05, 0x32, 0x2e, 0x25, 0x44, 0xc1, 0xb5, 0xa0, 0xae, 0xb6, 0xf0, 0x6e, 0xa5, 0x5f,
+	0xd9, 0x5e, 0xdc, 0x35, 0x13, 0xdb, 0x66, 0xf9, 0x7b, 0x1f, 0xda, 0x0c, 0x7f, 0x35, 0xf1, 0x18,
+	0xda, 0x63, 0xc2, 0x05, 0x32, 0xa5, 0xbd, 0x61, 0xfd, 0x43, 0x83, 0xd5, 0x94, 0xfa, 0x7d, 0x3a,
+	0x0e, 0x7d, 0x14, 0xe8, 0x9a, 0x9f, 0x40, 0x9d, 0x21, 0x9f, 0xf8, 0x11, 0x2a, 0xed, 0xdd, 0x5e,
+	0x22, 0xf4, 0x27, 0x21, 0x32, 0x22, 0x3d, 0x18, 0x28, 0x06, 0x19, 0xdc, 0x98, 0xa6, 0xc0, 0x69,
+	0x9a, 0x1b, 0x60, 0x0e, 0x3d, 0xc6, 0x85, 0x9d, 0xc9, 0xc4, 0xe8, 0x1c, 0xf4, 0xa0, 0xe3, 0x93,
+	0x79, 0x52, 0x74, 0x06, 0x8a, 0x30, 0xaa, 0xa9, 0x6c, 0x29, 0x80, 0xb5, 0xae, 0x08, 0x1f, 0xc3,
+	0xb2, 0x33, 0x61, 0x4c, 0x8a, 0x9a, 0x62, 0xb2, 0xd0, 0xd7, 0xb6, 0x6b, 0x83, 0x76, 0xbc, 0xfc,
+	0xd3, 0x68, 0xd5, 0x0a, 0xa0, 0x75, 0x80, 0xd2, 0xb3, 0x63, 0x05, 0xeb, 0x9b, 0xc0, 0x9c, 0x87,
+	0x50, 0x7a, 0xd4, 0x30, 0x57, 0x60, 0xf1, 0x8c, 0x30, 0xd7, 0x76, 0x95, 0xfc, 0x6e, 0xb5, 0xaf,
+	0x6d, 0x37, 0xac, 0x2f, 0x35, 0x58, 0x4b, 0x2b, 0x7c, 0x37, 0xc0, 0x16, 0x21, 0x54, 0x29, 0x43,
+	0xa8, 0xaa, 0x4e, 0xd4, 0x2f, 0xc0, 0x38, 0x61, 0x24, 0xe0, 0xc4, 0x91, 0x8b, 0xc7, 0x82, 0x30,
+	0xf1, 0x0e, 0x9d, 0xb7, 0x28, 0xf4, 0xe6, 0xc5, 0x27, 0xae, 0xde, 0x87, 0xb6, 0x48, 0x88, 0x53,
+	0x35, 0x95, 0x14, 0x04, 0xfa, 0x6b, 0x40, 0x20, 0x1d, 0x6d, 0x5a, 0xc3, 0x8c, 0x3f, 0x2a, 0x75,
+	0x4b, 0xf5, 0x24, 0x07, 0x43, 0x7f, 0x8d, 0x83, 0x51, 0xe4, 0x98, 0xd2, 0xf3, 0x7e, 0x1d, 0xdb,
+	0x87, 0x4e, 0x4a, 0xe1, 0xbe, 0x0a, 0x66, 0xa9, 0xa2, 0xbc, 0xd5, 0xba, 0xb2, 0xfa, 0xaf, 0x1a,
+	0x6c, 0xe4, 0xa4, 0xbc, 0x57, 0xbb, 0x4b, 0x0e, 0x7b, 0xb5, 0xfc, 0xb0, 0xd7, 0x4a, 0x0f, 0x7b,
+	0xbd, 0x2c, 0x95, 0x17, 0x54, 0x2a, 0xfb, 0xd0, 0x1c, 0x20, 0x89, 0x8b, 0xf6, 0x6b, 0x5e, 0x80,
+	0x5d, 0x30, 0x58, 0x54, 0xf4, 0x6d, 0x59, 0xc5, 0x6d, 0x41, 0x79, 0x7c, 0x7c, 0xcb, 0x2a, 0xe3,
+	0xbf, 0x35, 0x30, 0x67, 0xea, 0x12, 0x08, 0x3f, 0x9b, 0x3b, 0xbe, 0xdf, 0x4a, 0xa0, 0xca, 0x73,
+	0x27, 0x4b, 0x31, 0x7a, 0x9f, 0x4e, 0xaf, 0x1a, 0x5d, 0x5d, 0x35, 0x5f, 0x4b, 0x6f, 0x2e, 0xb8,
+	0xf6, 0x96, 0xa0, 0x86, 0x8c, 0x51, 0x16, 0xa7, 0xc8, 0xe7, 0xb0, 0x3c, 0x2f, 0x70, 0x11, 0x16,
+	0x8e, 0x27, 0x8e, 0x83, 0x9c, 0x1b, 0xf7, 0xcc, 0x16, 0x34, 0x8e, 0xa8, 0x38, 0xa4, 0x93, 0xc0,
+	0x35, 0xb4, 0xe8, 0x2b, 0xaa, 0x3e, 0x86, 0x6e, 0x76, 0x60, 0x29, 0xfa, 0x1d, 0x55, 0x25, 0xd7,
+	0xa8, 0x98, 0x4d, 0xa8, 0x3d, 0x95, 0xd2, 0x8d, 0xaa, 0x69, 0x40, 0x6b, 0x4f, 0x49, 0x39, 0xc0,
+	0xc0, 0x43, 0xd7, 0xa8, 0x59, 0xbf, 0xd7, 0xc0, 0x90, 0xca, 0xa2, 0x4d, 0xb7, 0x5d, 0x4e, 0x3d,
+	0xe8, 0x0c, 0x19, 0x1d, 0xdb, 0x05, 0xc8, 0x77, 0xa0, 0x39, 0x26, 0x57, 0xb6, 0x43, 0x27, 0x81,
+	0xe8, 0x56, 0x4a, 0x83, 0x51, 0x2d, 0x09, 0x46, 0x4d, 0x05, 0xe3, 0x3f, 0x3a, 0xf4, 0xe6, 0xad,
+	0x49, 0x62, 0xb2, 0x33, 0x3b, 0xe7, 0x5a, 0xbf, 0x72, 0x07, 0x58, 0x0f, 0xe6, 0xd2, 0xfd, 0x3b,
+	0xd9, 0x18, 0x16, 0x2a, 0x49, 0x51, 0x62, 0xe8, 0x7b, 0xd0, 0x09, 0xf0, 0xea, 0x0d, 0x2e, 0x39,
+	0x8f, 0xdb, 0x18, 0xb8, 0x36, 0x1d, 0xc6, 0x30, 0x46, 0x3e, 0x9a, 0x9b, 0xb0, 0xaa, 0x36, 0xe5,
+	0x6f, 0x3a, 0x79, 0x44, 0x67, 0xa9, 0xb0, 0xa0, 0x52, 0x21, 0x00, 0x23, 0x67, 0x50, 0x3e, 0x17,
+	0xe2, 0xe8, 0x6b, 0xf9, 0xe8, 0xeb, 0xe6, 0x32, 0x2c, 0x1e, 0x51, 0xf1, 0x9c, 0xba, 0xde, 0xd0,
+	0xbb, 0x3d, 0x1d, 0x7e, 0xab, 0x5e, 0x4d, 0xc4, 0xdd, 0xf3, 0xfd, 0x24, 0x17, 0xe6, 0x2d, 0xd5,
+	0x4a, 0x9f, 0x3a, 0xba, 0xa2, 0xbc, 0x93, 0x54, 0xf8, 0x8b, 0x0e, 0xf7, 0x33, 0x96, 0x24, 0x79,
+	0xf0, 0x06, 0x26, 0x7d, 0x3c, 0xf7, 0x76, 0x5a, 0xcf, 0xa7, 0x4e, 0x94, 0x33, 0x9b, 0xb0, 0xaa,
+	0xa2, 0x9d, 0xbf, 0x65, 0xa5, 0x98, 0x2d, 0x58, 0x53, 0xd4, 0x82, 0xf7, 0x8b, 0x24, 0xff, 0x60,
+	0x96, 0x70, 0xb2, 0xc4, 0xb5, 0x77, 0x77, 0xb2, 0x09, 0x97, 0x77, 0x65, 0xba, 0x1c, 0x45, 0xf6,
+	0xf1, 0x34, 0xae, 0xf3, 0x59, 0xf0, 0x23, 0x58, 0xca, 0x30, 0x66, 0x53, 0x60, 0x2e, 0xc2, 0x5a,
+	0x12, 0x61, 0x3d, 0x17, 0xe1, 0x8a, 0xf5, 0xf7, 0x0a, 0x6c, 0xee, 0xab, 0x27, 0xf9, 0x0b, 0x79,
+	0xf3, 0x73, 0x81, 0x81, 0x38, 0x9e, 0x9c, 0x72, 0x87, 0x79, 0xa1, 0x74, 0xc5, 0x7c, 0x00, 0xeb,
+	0x3c, 0xf5, 0x6d, 0x8f, 0x18, 0x9d, 0x84, 0x76, 0x40, 0xc6, 0x18, 0x17, 0x81, 0x82, 0xea, 0xa0,
+	0x4f, 0x9f, 0x15, 0x25, 0xc5, 0xd7, 0x04, 0xe0, 0xf2, 0xcd, 0x60, 0xcb, 0xea, 0x11, 0x9f, 0x90,
+	0x8f, 0x60, 0x33, 0x46, 0xc7, 0x16, 0xde, 0x18, 0xe9, 0x44, 0xd8, 0x63, 0xcf, 0xf7, 0x3d, 0x8e,
+	0x0e, 0x0d, 0x5c, 0x1e, 0xdf, 0x1f, 0x3d, 0xe8, 0x30, 0xf5, 0xe6, 0xb6, 0xb9, 0x20, 0xc2, 0xe3,
+	0xc2, 0x73, 0xb8, 0x3a, 0x2a, 0x0d, 0xa9, 0xce, 0xf7, 0x2e, 0xd0, 0x3e, 0x9d, 0x0c, 0x87, 0xc8,
+	0x6c, 0xee, 0x7d, 0x81, 0xaa, 0x31, 0xa9, 0x49, 0x0b, 0x19, 0x12, 0xd7, 0x3e, 0x25, 0xc2, 0x39,
+	0x8b, 0x08, 0x0d, 0x45, 0x58, 0x81, 0xc5, 0x34, 0x77, 0x73, 0xca, 0x2d, 0xd3, 0x95, 0xa1, 0x60,
+	0xd7, 0x71, 0xd2, 0x82, 0x22, 0x6c, 0x80, 0x19, 0x32, 0x94, 0xdc, 0x4c, 0xd6, 0x5a, 0x9b, 0xd1,
+	0x53, 0x2f, 0xe8, 0x2e, 0x2a, 0xe5, 0x5b, 0xb0, 0xe6, 0x9c, 0xa1, 0x73, 0x1e, 0x52, 0x2f, 0x10,
+	0x36, 0x19, 0x0a, 0x64, 0xca, 0x8d, 0x6e, 0x4b, 0x6d, 0xdd, 0x84, 0xd5, 0x14, 0x39, 0x39, 0x0d,
+	0x4b, 0x45, 0x54, 0x2f, 0x88, 0xa9, 0xed, 0x29, 0x35, 0x0e, 0xc0, 0x29, 0xb2, 0xd4, 0xde, 0x65,
+	0x45, 0x7d, 0x00, 0xeb, 0x32, 0x16, 0xae, 0x6c, 0xcf, 0xf8, 0x64, 0x2c, 0x5d, 0x11, 0x8c, 0x08,
+	0x1c, 0x5d, 0x77, 0x0d, 0x95, 0x2c, 0x3f, 0x83, 0xcd, 0xe8, 0xf4, 0xbf, 0xeb, 0xf8, 0xaa, 0xd4,
+	0x79, 0x19, 0xba, 0xff, 0x4f, 0x9d, 0xff, 0xd5, 0xd4, 0xf9, 0x8d, 0x0e, 0x0f, 0x6f, 0x0a, 0x70,
+	0x52, 0x82, 0xcf, 0xe7, 0x9e, 0x47, 0x83, 0xa4, 0xd2, 0xdd, 0x49, 0xc0, 0x8d, 0x5c, 0xf3, 0xd5,
+	0xb0, 0x2d, 0x95, 0x11, 0xae, 0x8a, 0xb9, 0x34, 0xd3, 0x06, 0xeb, 0xf6, 0xed, 0xd9, 0x1a, 0x69,
+	0x40, 0xeb, 0x80, 0x22, 0x3f, 0xa2, 0xe2, 0xe9, 0x95, 0xc7, 0x85, 0xa1, 0x99, 0x0d, 0xa8, 0x1e,
+	0x12, 0xcf, 0x2f, 0xac, 0x91, 0x5f, 0xea, 0xf0, 0xf0, 0xa6, 0x1a, 0x79, 0x27, 0x1c, 0xee, 0x24,
+	0xe0, 0x46, 0xae, 0xdb, 0x70, 0xf8, 0x25, 0x58, 0xb7, 0x6f, 0xcf, 0xe2, 0xd0, 0x81, 0xa5, 0x3d,
+	0x5f, 0x26, 0xf8, 0xb5, 0xc2, 0x81, 0xdf, 0x02, 0x84, 0x4c, 0x88, 0x9b, 0x8a, 0xc9, 0x9d, 0x80,
+	0xb8, 0x93, 0x80, 0x1b, 0xb9, 0xee, 0x90, 0x10, 0xb7, 0x6f, 0x7f, 0x9b, 0x84, 0xb8, 0x84, 0x07,
+	0xfb, 0x34, 0x08, 0xd0, 0x11, 0x27, 0xb4, 0xa4, 0xf6, 0xad, 0xc3, 0x72, 0xa6, 0xf6, 0x79, 0xee,
+	0x6d, 0x35, 0xcf, 0x82, 0x0d, 0xe2, 0xfb, 0xf4, 0x12, 0x5d, 0xdb, 0x0b, 0xec, 0xa1, 0xef, 0x8d,
+	0xce, 0x84, 0x3d, 0x45, 0x2d, 0x7a, 0x37, 0x59, 0xaf, 0xe0, 0x41, 0xb1, 0xbe, 0x3d, 0xe7, 0x3c,
+	0x79, 0xa0, 0x15, 0x2b, 0xfe, 0x00, 0x56, 0x42, 0x46, 0xa5, 0x1f, 0xe8, 0xda, 0xd3, 0xb1, 0x60,
+	0xd4, 0x23, 0xb7, 0xac, 0x7f, 0x69, 0x65, 0x92, 0x8f, 0xc8, 0x5b, 0x49, 0xce, 0x77, 0x8e, 0x3f,
+	0x86, 0x7a, 0xd4, 0x88, 0xaa, 0xea, 0xdd, 0xde, 0xfd, 0x76, 0x92, 0x1a, 0xb7, 0x58, 0xb0, 0x73,
+	0x44, 0xce, 0xf7, 0xd4, 0xd6, 0xc7, 0x0b, 0x2f, 0x83, 0xf3, 0x80, 0x5e, 0x06, 0xd6, 0x1e, 0x34,
+	0x67, 0xab, 0x32, 0xa4, 0xf1, 0xba, 0x71, 0x4f, 0x06, 0xf0, 0x05, 0x61, 0xe7, 0xd1, 0x03, 0x68,
+	0x20, 0x4b, 0xb2, 0xa1, 0xcb, 0xc5, 0xe3, 0x73, 0x2f, 0x34, 0x2a, 0xea, 0x97, 0xa0, 0xa1, 0x51,
+	0xb5, 0x2e, 0xc0, 0x2a, 0xcb, 0xc6, 0x60, 0xe8, 0xb1, 0xb1, 0xea, 0x83, 0x4b, 0xdf, 0xe9, 0xd1,
+	0x53, 0xb3, 0x00, 0x9b, 0xd9, 0x6c, 0x38, 0xdf, 0x13, 0x48, 0x20, 0x6a, 0xd6, 0xcf, 0xe1, 0x93,
+	0x62, 0xbd, 0xa9, 0x4e, 0x64, 0x2f, 0x0c, 0x91, 0x30, 0x74, 0x93, 0x16, 0x52, 0xbb, 0x4b, 0x0b,
+	0x69, 0x1d, 0x42, 0xe7, 0x78, 0x5a, 0xf9, 0x4f, 0xe8, 0x1d, 0xa6, 0x5f, 0xb9, 0xeb, 0x36, 0x9a,
+	0x2c, 0x1c, 0x43, 0xf7, 0x0d, 0x11, 0x29, 0x74, 0x5c, 0x57, 0x8e, 0x7f, 0x1f, 0x56, 0x8a, 0x5c,
+	0xfc, 0x46, 0xd6, 0xc5, 0xb2, 0x37, 0xb9, 0xb5, 0x0e, 0x6b, 0x2f, 0x83, 0xd9, 0xbd, 0x76, 0xc8,
+	0xe8, 0x38, 0x92, 0x66, 0xfd, 0x53, 0x83, 0x95, 0xb4, 0xb5, 0x07, 0x8c, 0x86, 0x21, 0xba, 0xe6,
+	0x60, 0x56, 0x1f, 0xb4, 0xbe, 0x96, 0x6d, 0xfc, 0x0a, 0xd8, 0x73, 0x6b, 0x03, 0xb5, 0xf7, 0x71,
+	0x2b, 0xa5, 0xd4, 0xb5, 0xfe, 0xa0, 0xc1, 0xfd, 0x62, 0x46, 0x59, 0x2f, 0xd2, 0xac, 0xc6, 0xbd,
+	0x5c, 0x05, 0xd1, 0x32, 0x3d, 0xbb, 0x6e, 0x7e, 0x1d, 0xb6, 0x8a, 0x33, 0x21, 0xe9, 0xda, 0xb7,
+	0xa0, 0x37, 0x0b, 0x28, 0x7b, 0x4e, 0xae, 0xf6, 0xe5, 0x45, 0x3e, 0x40, 0xe2, 0x9c, 0xa1, 0x6b,
+	0x54, 0xad, 0xaf, 0x74, 0x80, 0x23, 0x2a, 0x7e, 0x48, 0x02, 0xd7, 0x47, 0xd7, 0xfc, 0x6e, 0xca,
+	0x63, 0x79, 0xc6, 0x1e, 0xa6, 0x46, 0x60, 0x33, 0xae, 0xd4, 0xcf, 0xd8, 0xf2, 0x75, 0x58, 0x26,
+	0xae, 0xab, 0xa2, 0x48, 0x7c, 0xdb, 0x0b, 0x86, 0x54, 0x45, 0xac, 0xb5, 0xf1, 0x67, 0x0d, 0xe0,
+	0xb9, 0x6a, 0xc7, 0x9e, 0x05, 0x43, 0x2a, 0x23, 0x8f, 0x57, 0x02, 0x99, 0xe4, 0x12, 0x4e, 0x68,
+	0x13, 0xd7, 0x65, 0xc8, 0x79, 0x32, 0x15, 0xc8, 0x50, 0x43, 0xca, 0x44, 0x3c, 0x15, 0xd8, 0x82,
+	0xb5, 0x19, 0xe9, 0x4c, 0x88, 0x64, 0x67, 0x45, 0xed, 0xdc, 0x00, 0x33, 0x4b, 0x56, 0x5b, 0xa3,
+	0x47, 0xde, 0x87, 0xf0, 0xc1, 0x8c, 0xc6, 0xd1, 0x99, 0x30, 0xcc, 0xa8, 0xae, 0xa9, 0xda, 0xd2,
+	0x87, 0x6e, 0x11, 0x93, 0x12, 0x53, 0x57, 0xb9, 0xf7, 0x19, 0x18, 0x39, 0xb7, 0xa3, 0x60, 0xc8,
+	0x96, 0xea, 0xda, 0xb8, 0x27, 0x8b, 0xc8, 0x09, 0xa5, 0x4f, 0x26, 0xfc, 0xda, 0xd0, 0xcc, 0x25,
+	0x68, 0xca, 0x66, 0x4a, 0xb9, 0x6e, 0xe8, 0x96, 0x09, 0xc6, 0xb1, 0x43, 0x2e, 0x30, 0x18, 0xe1,
+	0x01, 0x11, 0xe4, 0x94, 0x70, 0xb4, 0xfe, 0xa6, 0x41, 0x6f, 0x7e, 0x31, 0xb9, 0x08, 0x9f, 0xcc,
+	0x5d, 0x84, 0xbb, 0xa9, 0xdc, 0x2b, 0xdb, 0x34, 0xa3, 0xc4, 0x37, 0xd5, 0xac, 0xfd, 0x8b, 0xc6,
+	0xc1, 0x6b, 0xb0, 0x24, 0xa8, 0x90, 0xe0, 0x7a, 0x63, 0xb4, 0xc7, 0x3c, 0x99, 0x3e, 0x44, 0xcb,
+	0x3c, 0x24, 0x0e, 0xda, 0x9c, 0x5c, 0xa0, 0x1b, 0xff, 0xd9, 0xf0, 0x3d, 0x68, 0xcf, 0x89, 0xcc,
+	0x5c, 0x7e, 0x6d, 0x80, 0x67, 0xc1, 0x0b, 0x46, 0x47, 0x12, 0x49, 0x43, 0x33, 0x01, 0xea, 0xf2,
+	0xea, 0x93, 0xf3, 0x82, 0x6f, 0xfe, 0x49, 0x83, 0xe5, 0xf9, 0x61, 0x60, 0x66, 0xb3, 0x09, 0xed,
+	0x17, 0x51, 0xfb, 0x7b, 0x12, 0xbd, 0xbd, 0xa3, 0xb9, 0x43, 0x34, 0x83, 0x9c, 0x2e, 0xe9, 0x92,
+	0xed, 0x90, 0xb2, 0x4b, 0xc2, 0xdc, 0xe9, 0x9a, 0xec, 0xcf, 0x57, 0x5f, 0x31, 0x1a, 0x8c, 0x9e,
+	0xc6, 0xc3, 0xe7, 0x78, 0x6a, 0x6f, 0x54, 0xf3, 0x83, 0x0b, 0x39, 0x8a, 0x36, 0x9f, 0x05, 0x17,
+	0xc4, 0xf7, 0xdc, 0xd4, 0xa4, 0xd3, 0xa8, 0xe7, 0xce, 0xd6, 0xc2, 0x7f, 0x07, 0x00, 0xdd, 0x3d,
+	0x18, 0xbd, 0x10, 0x1c, 0x00, 0x00,
 }
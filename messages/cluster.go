@@ -1,6 +1,7 @@
 package messages
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/satori/go.uuid"
 	"time"
@@ -87,3 +88,23 @@ var VNodeState_name = map[int]string{
 func (x VNodeState) String() string {
 	return VNodeState_name[int(x)]
 }
+
+// The server sends the state by name in its JSON gossip
+func (x *VNodeState) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		var value int
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*x = VNodeState(value)
+		return nil
+	}
+	for value, n := range VNodeState_name {
+		if n == name {
+			*x = VNodeState(value)
+			return nil
+		}
+	}
+	return fmt.Errorf("Unknown VNodeState '%s'", name)
+}
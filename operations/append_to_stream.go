@@ -94,3 +94,7 @@ func (o *appendToStream) createResponse() proto.Message {
 func (o *appendToStream) String() string {
 	return fmt.Sprintf("AppendToStream '%s'", o.stream)
 }
+
+func (o *appendToStream) IsIdempotent() bool {
+	return haveEventIds(o.events)
+}
@@ -263,3 +263,27 @@ func BenchmarkAppendToStreamBatchAsyncWithExpectedVersion_500(b *testing.B) {
 func BenchmarkAppendToStreamBatchAsyncWithExpectedVersion_1000(b *testing.B) {
 	AppendToStreamBatchAsyncWithExpectedVersion(1000, b)
 }
+
+func BenchmarkAppendToStreamParallel(b *testing.B) {
+	stream := "BenchmarkAppendToStreamParallel-" + uuid.Must(uuid.NewV4()).String()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			t, err := es.AppendToStreamAsync(
+				stream,
+				client.ExpectedVersion_Any,
+				[]*client.EventData{
+					client.NewEventData(uuid.Must(uuid.NewV4()), "Benchmark", true, []byte(`{}`), []byte(``)),
+				},
+				nil,
+			)
+			if err != nil {
+				b.Error(err)
+				return
+			}
+			if err := t.Wait(); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package operations
+
+import (
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"github.com/jdextraze/go-gesclient/client"
+	"github.com/jdextraze/go-gesclient/messages"
+	"github.com/jdextraze/go-gesclient/tasks"
+)
+
+// Same as appendToStream, but version mismatches and deleted streams complete with a *client.ConditionalWriteResult
+// instead of an error
+type conditionalAppendToStream struct {
+	*appendToStream
+}
+
+func NewConditionalAppendToStream(
+	source *tasks.CompletionSource,
+	requireMaster bool,
+	stream string,
+	expectedVersion int,
+	events []*client.EventData,
+	userCredentials *client.UserCredentials,
+) *conditionalAppendToStream {
+	obj := &conditionalAppendToStream{
+		appendToStream: NewAppendToStream(source, requireMaster, stream, expectedVersion, events, userCredentials),
+	}
+	obj.baseOperation = newBaseOperation(client.Command_WriteEvents, client.Command_WriteEventsCompleted,
+		userCredentials, source, obj.createRequestDto, obj.inspectResponse, obj.transformResponse, obj.createResponse)
+	return obj
+}
+
+func (o *conditionalAppendToStream) inspectResponse(message proto.Message) (*client.InspectionResult, error) {
+	msg := message.(*messages.WriteEventsCompleted)
+	switch msg.GetResult() {
+	case messages.OperationResult_WrongExpectedVersion, messages.OperationResult_StreamDeleted:
+		if err := o.succeed(); err != nil {
+			return nil, err
+		}
+		return client.NewInspectionResult(client.InspectionDecision_EndOperation, msg.GetResult().String(), nil,
+			nil), nil
+	default:
+		return o.appendToStream.inspectResponse(message)
+	}
+}
+
+func (o *conditionalAppendToStream) transformResponse(message proto.Message) (interface{}, error) {
+	msg := message.(*messages.WriteEventsCompleted)
+	var currentVersion *int
+	if msg.CurrentVersion != nil {
+		v := int(msg.GetCurrentVersion())
+		currentVersion = &v
+	}
+	switch msg.GetResult() {
+	case messages.OperationResult_Success:
+		lastEventNumber := int(msg.GetLastEventNumber())
+		pos := client.NewPosition(msg.GetCommitPosition(), msg.GetPreparePosition())
+		return client.NewConditionalWriteResult(client.ConditionalWriteStatus_Succeeded, lastEventNumber, pos,
+			&lastEventNumber), nil
+	case messages.OperationResult_WrongExpectedVersion:
+		return client.NewConditionalWriteResult(client.ConditionalWriteStatus_VersionMismatch, -1, nil,
+			currentVersion), nil
+	case messages.OperationResult_StreamDeleted:
+		return client.NewConditionalWriteResult(client.ConditionalWriteStatus_StreamDeleted, -1, nil,
+			currentVersion), nil
+	default:
+		return nil, fmt.Errorf("Unexpected OperationResult: %s", msg.GetResult())
+	}
+}
+
+func (o *conditionalAppendToStream) String() string {
+	return fmt.Sprintf("ConditionalAppendToStream '%s'", o.stream)
+}
@@ -94,6 +94,32 @@ func (o *baseOperation) InspectPackage(p *client.Package) (result *client.Inspec
 	return
 }
 
+func (o *baseOperation) RequestCommand() client.Command {
+	return o.requestCommand
+}
+
+func (o *baseOperation) IsIdempotent() bool {
+	switch o.requestCommand {
+	case client.Command_ReadEvent,
+		client.Command_ReadStreamEventsForward,
+		client.Command_ReadStreamEventsBackward,
+		client.Command_ReadAllEventsForward,
+		client.Command_ReadAllEventsBackward:
+		return true
+	default:
+		return false
+	}
+}
+
+func haveEventIds(events []*client.EventData) bool {
+	for _, e := range events {
+		if uuid.Equal(e.EventId(), uuid.Nil) {
+			return false
+		}
+	}
+	return true
+}
+
 func (o *baseOperation) succeed() error {
 	if atomic.CompareAndSwapInt32(&o.completed, 0, 1) {
 		if o.response != nil {
@@ -89,3 +89,7 @@ func (o *TransactionalWrite) createResponse() proto.Message {
 func (o *TransactionalWrite) String() string {
 	return fmt.Sprintf("Transactional write on transaction #%d", o.transactionId)
 }
+
+func (o *TransactionalWrite) IsIdempotent() bool {
+	return haveEventIds(o.events)
+}
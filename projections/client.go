@@ -9,79 +9,93 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
-	"time"
 )
 
 type client struct {
-	operationTimeout time.Duration
+	httpClient *http.Client
+	scheme     string
 }
 
-func newClient(operationTimeout time.Duration) *client {
+func newClient(httpClient *http.Client, useHttps bool) *client {
+	scheme := "http"
+	if useHttps {
+		scheme = "https"
+	}
 	return &client{
-		operationTimeout: operationTimeout,
+		httpClient: httpClient,
+		scheme:     scheme,
 	}
 }
 
 func (c *client) Enable(addr *net.TCPAddr, name string, userCredentials *cli.UserCredentials) *tasks.Task {
-	return c.sendPost(toHttpUrl(addr, "/projection/%s/command/enable", name), "", userCredentials, http.StatusOK)
+	return c.sendPost(c.toHttpUrl(addr, "/projection/%s/command/enable", name), "", userCredentials, http.StatusOK)
 }
 
 func (c *client) Disable(addr *net.TCPAddr, name string, userCredentials *cli.UserCredentials) *tasks.Task {
-	return c.sendPost(toHttpUrl(addr, "/projection/%s/command/disable", name), "", userCredentials, http.StatusOK)
+	return c.sendPost(c.toHttpUrl(addr, "/projection/%s/command/disable", name), "", userCredentials, http.StatusOK)
 }
 
 func (c *client) Abort(addr *net.TCPAddr, name string, userCredentials *cli.UserCredentials) *tasks.Task {
-	return c.sendPost(toHttpUrl(addr, "/projection/%s/command/abort", name), "", userCredentials, http.StatusOK)
+	return c.sendPost(c.toHttpUrl(addr, "/projection/%s/command/abort", name), "", userCredentials, http.StatusOK)
+}
+
+func (c *client) Reset(addr *net.TCPAddr, name string, userCredentials *cli.UserCredentials) *tasks.Task {
+	return c.sendPost(c.toHttpUrl(addr, "/projection/%s/command/reset", name), "", userCredentials, http.StatusOK)
 }
 
 func (c *client) CreateOneTime(addr *net.TCPAddr, query string, userCredentials *cli.UserCredentials) *tasks.Task {
-	return c.sendPost(toHttpUrl(addr, "/projections/oneTime?type=JS"), query, userCredentials, http.StatusCreated)
+	return c.sendPost(c.toHttpUrl(addr, "/projections/oneTime?type=JS"), query, userCredentials, http.StatusCreated)
 }
 
 func (c *client) CreateTransient(
 	addr *net.TCPAddr,
 	name string,
 	query string,
+	enabled bool,
 	userCredentials *cli.UserCredentials,
 ) *tasks.Task {
-	return c.sendPost(toHttpUrl(addr, "/projections/transient?name=%s&type=JS", name), query, userCredentials,
-		http.StatusCreated)
+	return c.sendPost(c.toHttpUrl(addr, "/projections/transient?name=%s&type=JS&enabled=%t", name, enabled), query,
+		userCredentials, http.StatusCreated)
 }
 
 func (c *client) CreateContinuous(
 	addr *net.TCPAddr,
 	name string,
 	query string,
+	emit bool,
 	trackEmitted bool,
+	enabled bool,
 	userCredentials *cli.UserCredentials,
 ) *tasks.Task {
 	return c.sendPost(
-		toHttpUrl(addr, "/projections/continuous?name=%s&type=JS&emit=1&trackemittedstreams=%t", name, trackEmitted),
+		c.toHttpUrl(addr, "/projections/continuous?name=%s&type=JS&emit=%d&trackemittedstreams=%t&enabled=%t", name,
+			boolToInt(emit), trackEmitted, enabled),
 		query, userCredentials, http.StatusCreated)
 }
 
 func (c *client) ListAll(addr *net.TCPAddr, userCredentials *cli.UserCredentials) *tasks.Task {
-	return c.sendGet(toHttpUrl(addr, "/projections/any"), userCredentials, http.StatusOK).
+	return c.sendGet(c.toHttpUrl(addr, "/projections/any"), userCredentials, http.StatusOK).
 		ContinueWith(getProjectionDetails)
 }
 
 func (c *client) ListOneTime(addr *net.TCPAddr, userCredentials *cli.UserCredentials) *tasks.Task {
-	return c.sendGet(toHttpUrl(addr, "/projections/onetime"), userCredentials, http.StatusOK).
+	return c.sendGet(c.toHttpUrl(addr, "/projections/onetime"), userCredentials, http.StatusOK).
 		ContinueWith(getProjectionDetails)
 }
 
 func (c *client) ListContinuous(addr *net.TCPAddr, userCredentials *cli.UserCredentials) *tasks.Task {
-	return c.sendGet(toHttpUrl(addr, "/projections/continuous"), userCredentials, http.StatusOK).
+	return c.sendGet(c.toHttpUrl(addr, "/projections/continuous"), userCredentials, http.StatusOK).
 		ContinueWith(getProjectionDetails)
 }
 
 func (c *client) GetStatus(addr *net.TCPAddr, name string, userCredentials *cli.UserCredentials) *tasks.Task {
-	return c.sendGet(toHttpUrl(addr, "/projection/%s", name), userCredentials, http.StatusOK)
+	return c.sendGet(c.toHttpUrl(addr, "/projection/%s", name), userCredentials, http.StatusOK)
 }
 
 func (c *client) GetState(addr *net.TCPAddr, name string, userCredentials *cli.UserCredentials) *tasks.Task {
-	return c.sendGet(toHttpUrl(addr, "/projection/%s/state", name), userCredentials, http.StatusOK)
+	return c.sendGet(c.toHttpUrl(addr, "/projection/%s/state", name), userCredentials, http.StatusOK)
 }
 
 func (c *client) GetPartitionStateAsync(
@@ -90,12 +104,12 @@ func (c *client) GetPartitionStateAsync(
 	partition string,
 	userCredentials *cli.UserCredentials,
 ) *tasks.Task {
-	return c.sendGet(toHttpUrl(addr, "/projection/%s/state?partition=%s", name, partition), userCredentials,
+	return c.sendGet(c.toHttpUrl(addr, "/projection/%s/state?partition=%s", name, partition), userCredentials,
 		http.StatusOK)
 }
 
 func (c *client) GetResult(addr *net.TCPAddr, name string, userCredentials *cli.UserCredentials) *tasks.Task {
-	return c.sendGet(toHttpUrl(addr, "/projection/%s/result", name), userCredentials, http.StatusOK)
+	return c.sendGet(c.toHttpUrl(addr, "/projection/%s/result", name), userCredentials, http.StatusOK)
 }
 
 func (c *client) GetPartitionResultAsync(
@@ -104,16 +118,41 @@ func (c *client) GetPartitionResultAsync(
 	partition string,
 	userCredentials *cli.UserCredentials,
 ) *tasks.Task {
-	return c.sendGet(toHttpUrl(addr, "/projection/%s/result?partition=%s", name, partition), userCredentials,
+	return c.sendGet(c.toHttpUrl(addr, "/projection/%s/result?partition=%s", name, partition), userCredentials,
 		http.StatusOK)
 }
 
 func (c *client) GetStatistics(addr *net.TCPAddr, name string, userCredentials *cli.UserCredentials) *tasks.Task {
-	return c.sendGet(toHttpUrl(addr, "/projection/%s/statistics", name), userCredentials, http.StatusOK)
+	return c.sendGet(c.toHttpUrl(addr, "/projection/%s/statistics", name), userCredentials, http.StatusOK).
+		ContinueWith(getProjectionStatistics)
+}
+
+// Partitions are recorded by the server in the partition catalog stream of the projection
+func (c *client) ListPartitions(addr *net.TCPAddr, name string, userCredentials *cli.UserCredentials) *tasks.Task {
+	return tasks.New(func() (interface{}, error) {
+		stream := fmt.Sprintf("$projections-%s-partitions", name)
+		var partitions []string
+		seen := map[string]bool{}
+		for from := 0; ; from += partitionsPageSize {
+			entries, err := c.readStreamForward(addr, stream, from, partitionsPageSize, userCredentials)
+			if err != nil {
+				return nil, err
+			}
+			for _, e := range entries {
+				if partition := e.dataString(); !seen[partition] {
+					seen[partition] = true
+					partitions = append(partitions, partition)
+				}
+			}
+			if len(entries) < partitionsPageSize {
+				return partitions, nil
+			}
+		}
+	})
 }
 
 func (c *client) GetQuery(addr *net.TCPAddr, name string, userCredentials *cli.UserCredentials) *tasks.Task {
-	return c.sendGet(toHttpUrl(addr, "/projection/%s/query", name), userCredentials, http.StatusOK)
+	return c.sendGet(c.toHttpUrl(addr, "/projection/%s/query", name), userCredentials, http.StatusOK)
 }
 
 func (c *client) UpdateQuery(
@@ -122,7 +161,36 @@ func (c *client) UpdateQuery(
 	query string,
 	userCredentials *cli.UserCredentials,
 ) *tasks.Task {
-	return c.sendPut(toHttpUrl(addr, "/projection/%s/query?type=JS", name), query, userCredentials, http.StatusOK)
+	return c.sendPut(c.toHttpUrl(addr, "/projection/%s/query?type=JS", name), query, userCredentials, http.StatusOK)
+}
+
+func (c *client) UpdateQueryEmit(
+	addr *net.TCPAddr,
+	name string,
+	query string,
+	emitEnabled bool,
+	userCredentials *cli.UserCredentials,
+) *tasks.Task {
+	return c.sendPut(c.toHttpUrl(addr, "/projection/%s/query?type=JS&emit=%d", name, boolToInt(emitEnabled)), query,
+		userCredentials, http.StatusOK)
+}
+
+func (c *client) GetConfig(addr *net.TCPAddr, name string, userCredentials *cli.UserCredentials) *tasks.Task {
+	return c.sendGet(c.toHttpUrl(addr, "/projection/%s/config", name), userCredentials, http.StatusOK).
+		ContinueWith(getProjectionConfig)
+}
+
+func (c *client) UpdateConfig(
+	addr *net.TCPAddr,
+	name string,
+	config *ProjectionConfig,
+	userCredentials *cli.UserCredentials,
+) *tasks.Task {
+	body, err := json.Marshal(config)
+	if err != nil {
+		return tasks.New(func() (interface{}, error) { return nil, err })
+	}
+	return c.sendPut(c.toHttpUrl(addr, "/projection/%s/config", name), string(body), userCredentials, http.StatusOK)
 }
 
 func (c *client) Delete(
@@ -131,7 +199,7 @@ func (c *client) Delete(
 	deleteEmittedStreams bool,
 	userCredentials *cli.UserCredentials,
 ) *tasks.Task {
-	return c.sendDelete(toHttpUrl(addr, "/projection/%s?deleteEmittedStreams=%t", name, deleteEmittedStreams),
+	return c.sendDelete(c.toHttpUrl(addr, "/projection/%s?deleteEmittedStreams=%t", name, deleteEmittedStreams),
 		userCredentials, http.StatusOK)
 }
 
@@ -150,17 +218,17 @@ func (c *client) sendGet(
 			req.SetBasicAuth(userCredentials.Username(), userCredentials.Password())
 		}
 
-		res, err := http.DefaultClient.Do(req)
+		res, err := c.httpClient.Do(req)
 		if err != nil {
 			return nil, err
 		}
+		defer res.Body.Close()
 
 		if res.StatusCode == expectedCode {
 			body, _ := ioutil.ReadAll(res.Body)
 			return string(body), nil
 		} else {
-			return nil, fmt.Errorf("projection command failed. server returned %d (%s) for GET on %s",
-				res.StatusCode, res.Status, reqUrl.String())
+			return nil, newHttpError(req, res)
 		}
 	})
 }
@@ -180,16 +248,16 @@ func (c *client) sendDelete(
 			req.SetBasicAuth(userCredentials.Username(), userCredentials.Password())
 		}
 
-		res, err := http.DefaultClient.Do(req)
+		res, err := c.httpClient.Do(req)
 		if err != nil {
 			return nil, err
 		}
+		defer res.Body.Close()
 
 		if res.StatusCode == expectedCode {
 			return nil, nil
 		} else {
-			return nil, fmt.Errorf("projection command failed. server returned %d (%s) for GET on %s",
-				res.StatusCode, res.Status, reqUrl.String())
+			return nil, newHttpError(req, res)
 		}
 	})
 }
@@ -211,18 +279,16 @@ func (c *client) sendPost(
 			req.SetBasicAuth(userCredentials.Username(), userCredentials.Password())
 		}
 
-		res, err := http.DefaultClient.Do(req)
+		res, err := c.httpClient.Do(req)
 		if err != nil {
 			return nil, err
 		}
+		defer res.Body.Close()
 
 		if res.StatusCode == expectedCode {
 			return nil, nil
-		} else if res.StatusCode == http.StatusConflict {
-			return nil, fmt.Errorf("projection command conflict: %s", res.Status)
 		} else {
-			return nil, fmt.Errorf("projection command failed. server returned %d (%s) for POST on %s",
-				res.StatusCode, res.Status, reqUrl.String())
+			return nil, newHttpError(req, res)
 		}
 	})
 }
@@ -244,26 +310,152 @@ func (c *client) sendPut(
 			req.SetBasicAuth(userCredentials.Username(), userCredentials.Password())
 		}
 
-		res, err := http.DefaultClient.Do(req)
+		res, err := c.httpClient.Do(req)
 		if err != nil {
 			return nil, err
 		}
+		defer res.Body.Close()
 
 		if res.StatusCode == expectedCode {
 			return nil, nil
 		} else {
-			return nil, fmt.Errorf("projection command failed. server returned %d (%s) for PUT on %s",
-				res.StatusCode, res.Status, reqUrl.String())
+			return nil, newHttpError(req, res)
 		}
 	})
 }
 
-func toHttpUrl(addr *net.TCPAddr, pathFormat string, args ...interface{}) *url.URL {
-	return &url.URL{
-		Scheme: "http",
+func newHttpError(req *http.Request, res *http.Response) error {
+	body, _ := ioutil.ReadAll(res.Body)
+	return cli.NewHttpError(res.StatusCode, req.Method, req.URL.String(), string(body))
+}
+
+const partitionsPageSize = 100
+
+type feedEntry struct {
+	EventNumber int
+	Data        json.RawMessage
+}
+
+// Data is a JSON value for JSON events and a string otherwise
+func (e *feedEntry) dataString() string {
+	var s string
+	if err := json.Unmarshal(e.Data, &s); err != nil {
+		return string(e.Data)
+	}
+	return s
+}
+
+// Returns the entries ordered by event number. A stream that doesn't exist has no entries.
+func (c *client) readStreamForward(
+	addr *net.TCPAddr,
+	stream string,
+	from int,
+	count int,
+	userCredentials *cli.UserCredentials,
+) ([]*feedEntry, error) {
+	reqUrl := c.toHttpUrl(addr, "/streams/%s/%d/forward/%d?embed=body", stream, from, count)
+	req, err := http.NewRequest(http.MethodGet, reqUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Accept", "application/vnd.eventstore.atom+json")
+	if userCredentials != nil {
+		req.SetBasicAuth(userCredentials.Username(), userCredentials.Password())
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	} else if res.StatusCode != http.StatusOK {
+		return nil, newHttpError(req, res)
+	}
+	feed := struct{ Entries []*feedEntry }{}
+	if err := json.NewDecoder(res.Body).Decode(&feed); err != nil {
+		return nil, err
+	}
+	sort.Slice(feed.Entries, func(i, j int) bool { return feed.Entries[i].EventNumber < feed.Entries[j].EventNumber })
+	return feed.Entries, nil
+}
+
+// Escapes the string arguments according to where they appear in the format, either in the path or in the query
+func (c *client) toHttpUrl(addr *net.TCPAddr, format string, args ...interface{}) *url.URL {
+	pathFormat, queryFormat := format, ""
+	if i := strings.Index(format, "?"); i >= 0 {
+		pathFormat, queryFormat = format[:i], format[i+1:]
+	}
+	pathArgs := make([]interface{}, strings.Count(pathFormat, "%"))
+	queryArgs := make([]interface{}, len(args)-len(pathArgs))
+	for i, arg := range args {
+		if i < len(pathArgs) {
+			if s, ok := arg.(string); ok {
+				arg = url.PathEscape(s)
+			}
+			pathArgs[i] = arg
+		} else {
+			if s, ok := arg.(string); ok {
+				arg = url.QueryEscape(s)
+			}
+			queryArgs[i-len(pathArgs)] = arg
+		}
+	}
+	rawPath := fmt.Sprintf(pathFormat, pathArgs...)
+	path, _ := url.PathUnescape(rawPath)
+	u := &url.URL{
+		Scheme: c.scheme,
 		Host:   addr.String(),
-		Path:   fmt.Sprintf(pathFormat, args...),
+		Path:   path,
+	}
+	if u.EscapedPath() != rawPath {
+		u.RawPath = rawPath
+	}
+	if queryFormat != "" {
+		u.RawQuery = fmt.Sprintf(queryFormat, queryArgs...)
+	}
+	return u
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
 	}
+	return 0
+}
+
+func getProjectionConfig(t *tasks.Task) (interface{}, error) {
+	if t.IsFaulted() {
+		return nil, t.Error()
+	}
+
+	body := t.Result().(string)
+	config := &ProjectionConfig{}
+	if err := json.Unmarshal([]byte(body), config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func getProjectionStatistics(t *tasks.Task) (interface{}, error) {
+	if t.IsFaulted() {
+		return nil, t.Error()
+	}
+
+	body := t.Result().(string)
+	data := statisticsResult{}
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		return nil, err
+	}
+	if len(data.Projections) == 0 {
+		return nil, fmt.Errorf("no statistics returned by the server")
+	}
+
+	return data.Projections[0], nil
 }
 
 func getProjectionDetails(t *tasks.Task) (interface{}, error) {
@@ -0,0 +1,20 @@
+package projections
+
+import (
+	"encoding/json"
+	"github.com/jdextraze/go-gesclient/tasks"
+)
+
+// Waits for a task returning a JSON string, like GetStateAsync, GetResultAsync or their partition variants, and
+// decodes its result into v. v is left untouched when the result is empty, which is the case before the projection
+// processed any event.
+func Decode(t *tasks.Task, v interface{}) error {
+	if err := t.Wait(); err != nil {
+		return err
+	}
+	body, _ := t.Result().(string)
+	if body == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(body), v)
+}
@@ -0,0 +1,392 @@
+package projections
+
+import (
+	"encoding/json"
+	"fmt"
+	cli "github.com/jdextraze/go-gesclient/client"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Runs a LocalQuery in process on catch-up subscriptions, keeping the state of every partition in memory and
+// checkpointing them with the position reached in the source to a StateStore. Events are processed at least once: the
+// events after the last checkpoint are processed again when the projection restarts.
+type LocalProjection struct {
+	connection         cli.Connection
+	name               string
+	query              *LocalQuery
+	store              StateStore
+	checkpointAfter    int
+	checkpointInterval time.Duration
+	userCredentials    *cli.UserCredentials
+	checkpointStream   string
+
+	lock              sync.Mutex
+	started           bool
+	running           bool
+	checkpoint        *Checkpoint
+	states            map[string]interface{}
+	savedStates       map[string]json.RawMessage
+	dirty             map[string]bool
+	pending           int
+	subscriptions     []cli.CatchUpSubscription
+	liveSubscriptions map[cli.CatchUpSubscription]bool
+	live              chan struct{}
+	stop              chan struct{}
+	done              chan struct{}
+	doneOnce          sync.Once
+	err               error
+}
+
+// A checkpoint is saved after checkpointAfter events or every checkpointInterval when events were processed since the
+// last one, and when the projection stops. A nil store keeps the checkpoints in the <name>-checkpoint stream. The events
+// of the checkpoint stream of a stream store are never processed.
+func NewLocalProjection(
+	connection cli.Connection,
+	name string,
+	query *LocalQuery,
+	store StateStore,
+	checkpointAfter int,
+	checkpointInterval time.Duration,
+	userCredentials *cli.UserCredentials,
+) *LocalProjection {
+	if connection == nil {
+		panic("connection is nil")
+	}
+	if name == "" {
+		panic("name must be present")
+	}
+	if query == nil {
+		panic("query is nil")
+	}
+	if checkpointAfter <= 0 {
+		panic("checkpointAfter should be positive")
+	}
+	if checkpointInterval <= 0 {
+		panic("checkpointInterval should be positive")
+	}
+	if store == nil {
+		store = NewStreamStateStore(connection, name+"-checkpoint", userCredentials)
+	}
+
+	return &LocalProjection{
+		connection:         connection,
+		name:               name,
+		query:              query,
+		store:              store,
+		checkpointAfter:    checkpointAfter,
+		checkpointInterval: checkpointInterval,
+		userCredentials:    userCredentials,
+		checkpointStream:   checkpointStreamOf(store),
+		states:             map[string]interface{}{},
+		dirty:              map[string]bool{},
+		liveSubscriptions:  map[cli.CatchUpSubscription]bool{},
+		live:               make(chan struct{}),
+		stop:               make(chan struct{}),
+		done:               make(chan struct{}),
+	}
+}
+
+func (p *LocalProjection) Name() string { return p.name }
+
+// Restores the last checkpoint and subscribes to the sources of the query. A projection can only be started once.
+func (p *LocalProjection) Start() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.started {
+		return fmt.Errorf("Local projection '%s' was already started", p.name)
+	}
+	p.started = true
+
+	checkpoint, states, err := p.store.Load()
+	if err != nil {
+		return err
+	}
+	if checkpoint == nil {
+		checkpoint = &Checkpoint{}
+	}
+	p.checkpoint = checkpoint.copy()
+	p.savedStates = states
+	p.running = true
+
+	if err := p.subscribe(); err != nil {
+		p.running = false
+		for _, s := range p.subscriptions {
+			s.Stop()
+		}
+		return err
+	}
+
+	go p.checkpointPeriodically()
+	return nil
+}
+
+func (p *LocalProjection) subscribe() error {
+	if p.query.fromAll {
+		settings := cli.NewCatchUpSubscriptionSettings(cli.CatchUpDefaultMaxPushQueueSize,
+			cli.CatchUpDefaultReadBatchSize, false, false)
+		s, err := p.connection.SubscribeToAllFrom(p.checkpoint.Position, settings, p.eventAppeared(""),
+			p.liveProcessingStarted, p.subscriptionDropped, p.userCredentials)
+		if err != nil {
+			return err
+		}
+		p.subscriptions = append(p.subscriptions, s)
+		return nil
+	}
+
+	for _, stream := range p.query.streams {
+		var lastCheckpoint *int
+		if eventNumber, found := p.checkpoint.Streams[stream]; found {
+			lastCheckpoint = &eventNumber
+		}
+		s, err := p.connection.SubscribeToStreamFrom(stream, lastCheckpoint, cli.CatchUpSubscriptionSettings_Default,
+			p.eventAppeared(stream), p.liveProcessingStarted, p.subscriptionDropped, p.userCredentials)
+		if err != nil {
+			return err
+		}
+		p.subscriptions = append(p.subscriptions, s)
+	}
+	return nil
+}
+
+// Stops the subscriptions and saves a last checkpoint. It returns the error that faulted the projection, if any.
+func (p *LocalProjection) Stop() error {
+	p.lock.Lock()
+	if !p.running {
+		p.lock.Unlock()
+		return p.Err()
+	}
+	p.running = false
+	close(p.stop)
+	subscriptions := p.subscriptions
+	p.lock.Unlock()
+
+	for _, s := range subscriptions {
+		s.Stop()
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	err := p.saveCheckpoint()
+	p.complete(err)
+	return err
+}
+
+// Closed once all the subscriptions caught up with their source and process live events
+func (p *LocalProjection) Live() <-chan struct{} { return p.live }
+
+// Closed when the projection stops or faults
+func (p *LocalProjection) Done() <-chan struct{} { return p.done }
+
+// Returns the error that faulted the projection, nil while it runs or when it was stopped normally
+func (p *LocalProjection) Err() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.err
+}
+
+// Returns the current state of the partition. Projections that are not partitioned have a single partition named "".
+func (p *LocalProjection) State(partition string) (interface{}, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.stateOf(partition)
+}
+
+// Returns a copy of the checkpoint of the last processed event
+func (p *LocalProjection) Checkpoint() *Checkpoint {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.checkpoint == nil {
+		return nil
+	}
+	return p.checkpoint.copy()
+}
+
+func (p *LocalProjection) eventAppeared(stream string) cli.CatchUpEventAppearedHandler {
+	return func(_ cli.CatchUpSubscription, evt *cli.ResolvedEvent) error {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+
+		if !p.running {
+			return nil
+		}
+		processed, err := p.process(evt)
+		if err != nil {
+			return err
+		}
+		if stream == "" {
+			p.checkpoint.Position = evt.OriginalPosition()
+		} else {
+			if p.checkpoint.Streams == nil {
+				p.checkpoint.Streams = map[string]int{}
+			}
+			p.checkpoint.Streams[stream] = evt.OriginalEventNumber()
+		}
+		// Skipped events only move the position, which is saved with the next checkpoint
+		if !processed {
+			return nil
+		}
+		p.pending++
+		if p.pending >= p.checkpointAfter {
+			return p.saveCheckpoint()
+		}
+		return nil
+	}
+}
+
+// Returns false when the event was skipped
+func (p *LocalProjection) process(evt *cli.ResolvedEvent) (bool, error) {
+	if evt.Event() != nil && evt.Event().EventStreamId() == p.checkpointStream {
+		return false, nil
+	}
+	handler := p.query.handlerFor(evt)
+	if handler == nil {
+		return false, nil
+	}
+	partition, ok := p.query.partitionOf(evt)
+	if !ok {
+		return false, nil
+	}
+	state, err := p.stateOf(partition)
+	if err != nil {
+		return false, err
+	}
+	state, err = handler(state, evt)
+	if err != nil {
+		return false, err
+	}
+	p.states[partition] = state
+	p.dirty[partition] = true
+	return true, nil
+}
+
+func (p *LocalProjection) stateOf(partition string) (interface{}, error) {
+	if state, found := p.states[partition]; found {
+		return state, nil
+	}
+	state := p.query.newState()
+	if data, found := p.savedStates[partition]; found {
+		var err error
+		if state, err = decodeState(data, state); err != nil {
+			return nil, fmt.Errorf("Failed decoding state of partition '%s': %v", partition, err)
+		}
+		delete(p.savedStates, partition)
+	}
+	p.states[partition] = state
+	return state, nil
+}
+
+// Decodes into the state returned by init, whether it is a pointer or a value
+func decodeState(data json.RawMessage, state interface{}) (interface{}, error) {
+	if state == nil {
+		err := json.Unmarshal(data, &state)
+		return state, err
+	}
+	if reflect.TypeOf(state).Kind() == reflect.Ptr {
+		return state, json.Unmarshal(data, state)
+	}
+	ptr := reflect.New(reflect.TypeOf(state))
+	ptr.Elem().Set(reflect.ValueOf(state))
+	if err := json.Unmarshal(data, ptr.Interface()); err != nil {
+		return nil, err
+	}
+	return ptr.Elem().Interface(), nil
+}
+
+func (p *LocalProjection) saveCheckpoint() error {
+	if p.pending == 0 {
+		return nil
+	}
+	states := make(map[string]json.RawMessage, len(p.dirty))
+	for partition := range p.dirty {
+		data, err := json.Marshal(p.states[partition])
+		if err != nil {
+			return fmt.Errorf("Failed encoding state of partition '%s': %v", partition, err)
+		}
+		states[partition] = data
+	}
+	if err := p.store.Save(p.checkpoint.copy(), states); err != nil {
+		return err
+	}
+	p.dirty = map[string]bool{}
+	p.pending = 0
+	return nil
+}
+
+func (p *LocalProjection) checkpointPeriodically() {
+	ticker := time.NewTicker(p.checkpointInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.lock.Lock()
+			var err error
+			if p.running {
+				err = p.saveCheckpoint()
+			}
+			p.lock.Unlock()
+			if err != nil {
+				p.fault(err)
+				return
+			}
+		}
+	}
+}
+
+// Called again by a subscription catching up after a reconnection
+func (p *LocalProjection) liveProcessingStarted(s cli.CatchUpSubscription) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.liveSubscriptions[s] {
+		return nil
+	}
+	p.liveSubscriptions[s] = true
+	if len(p.liveSubscriptions) == len(p.subscriptions) {
+		close(p.live)
+	}
+	return nil
+}
+
+func (p *LocalProjection) subscriptionDropped(
+	_ cli.CatchUpSubscription,
+	reason cli.SubscriptionDropReason,
+	err error,
+) error {
+	if reason == cli.SubscriptionDropReason_UserInitiated {
+		return nil
+	}
+	if err == nil {
+		err = fmt.Errorf("Subscription dropped: %s", reason)
+	}
+	p.fault(err)
+	return nil
+}
+
+// Stops the projection without saving a checkpoint, as the states may not match the position anymore
+func (p *LocalProjection) fault(err error) {
+	p.lock.Lock()
+	if !p.running {
+		p.lock.Unlock()
+		return
+	}
+	p.running = false
+	close(p.stop)
+	subscriptions := p.subscriptions
+	p.complete(err)
+	p.lock.Unlock()
+
+	for _, s := range subscriptions {
+		s.Stop()
+	}
+}
+
+func (p *LocalProjection) complete(err error) {
+	p.doneOnce.Do(func() {
+		p.err = err
+		close(p.done)
+	})
+}
@@ -0,0 +1,125 @@
+package projections
+
+import (
+	cli "github.com/jdextraze/go-gesclient/client"
+	"github.com/jdextraze/go-gesclient/common"
+	"strings"
+)
+
+// Returns the new state of the partition after applying the event to state
+type EventHandler func(state interface{}, evt *cli.ResolvedEvent) (interface{}, error)
+
+// Returns the partition of the event. Events for which it returns an empty string are skipped.
+type PartitionSelector func(evt *cli.ResolvedEvent) string
+
+// Definition of a projection run in process by a LocalProjection, modeled after the server's JS projections
+type LocalQuery struct {
+	fromAll     bool
+	streams     []string
+	init        func() interface{}
+	handlers    map[string]EventHandler
+	anyHandler  EventHandler
+	partitionBy PartitionSelector
+}
+
+// Events of system streams are skipped
+func FromAll() *LocalQuery {
+	return &LocalQuery{
+		fromAll:  true,
+		handlers: map[string]EventHandler{},
+	}
+}
+
+// Events of the different streams are processed one at a time, but their relative order is not defined
+func FromStreams(streams ...string) *LocalQuery {
+	if len(streams) == 0 {
+		panic("streams must be present")
+	}
+	for _, stream := range streams {
+		if stream == "" {
+			panic("stream must be present")
+		}
+	}
+	return &LocalQuery{
+		streams:  streams,
+		handlers: map[string]EventHandler{},
+	}
+}
+
+// Reads the $ce-<category> stream maintained by the $by_category system projection. Links to deleted events are
+// skipped.
+func FromCategory(category string) *LocalQuery {
+	if category == "" {
+		panic("category must be present")
+	}
+	return FromStreams(common.SystemStreams_CategoryStreamOf(category))
+}
+
+// The state of a new partition is nil unless an init function is set. When states are checkpointed, init must
+// return a value that encoding/json can decode into, or a pointer to one.
+func (q *LocalQuery) Init(init func() interface{}) *LocalQuery {
+	q.init = init
+	return q
+}
+
+func (q *LocalQuery) When(eventType string, handler EventHandler) *LocalQuery {
+	if eventType == "" {
+		panic("eventType must be present")
+	}
+	if handler == nil {
+		panic("handler is nil")
+	}
+	q.handlers[eventType] = handler
+	return q
+}
+
+// Handles the events that have no handler of their own
+func (q *LocalQuery) WhenAny(handler EventHandler) *LocalQuery {
+	if handler == nil {
+		panic("handler is nil")
+	}
+	q.anyHandler = handler
+	return q
+}
+
+func (q *LocalQuery) PartitionBy(partitionBy PartitionSelector) *LocalQuery {
+	if partitionBy == nil {
+		panic("partitionBy is nil")
+	}
+	q.partitionBy = partitionBy
+	return q
+}
+
+// Keeps a state per stream of the events
+func (q *LocalQuery) ForeachStream() *LocalQuery {
+	q.partitionBy = func(evt *cli.ResolvedEvent) string { return evt.Event().EventStreamId() }
+	return q
+}
+
+func (q *LocalQuery) handlerFor(evt *cli.ResolvedEvent) EventHandler {
+	if evt.Event() == nil {
+		return nil
+	}
+	if q.fromAll && strings.HasPrefix(evt.Event().EventStreamId(), "$") {
+		return nil
+	}
+	if handler, found := q.handlers[evt.Event().EventType()]; found {
+		return handler
+	}
+	return q.anyHandler
+}
+
+func (q *LocalQuery) partitionOf(evt *cli.ResolvedEvent) (string, bool) {
+	if q.partitionBy == nil {
+		return "", true
+	}
+	partition := q.partitionBy(evt)
+	return partition, partition != ""
+}
+
+func (q *LocalQuery) newState() interface{} {
+	if q.init == nil {
+		return nil
+	}
+	return q.init()
+}
@@ -1,15 +1,21 @@
 package projections
 
 import (
+	"errors"
 	cli "github.com/jdextraze/go-gesclient/client"
+	"github.com/jdextraze/go-gesclient/internal"
 	"github.com/jdextraze/go-gesclient/tasks"
 	"net"
+	"net/http"
+	"sync"
 	"time"
 )
 
 type Manager struct {
-	client       *client
-	httpEndpoint *net.TCPAddr
+	client             *client
+	httpEndpoint       *net.TCPAddr
+	endpointDiscoverer internal.EndpointDiscoverer
+	discoverLock       sync.Mutex
 }
 
 func NewManager(
@@ -20,10 +26,74 @@ func NewManager(
 		panic("httpEndpoint is nil")
 	}
 
+	return NewManagerFromSettings(CreateManagerSettings().
+		SetHttpEndpoint(httpEndpoint).
+		SetOperationTimeoutTo(operationTimeout).
+		Build())
+}
+
+func NewManagerFromSettings(settings *ManagerSettings) *Manager {
+	if settings == nil {
+		panic("settings is nil")
+	}
+
+	httpClient := settings.HttpClient()
+	if httpClient == nil {
+		transport := http.DefaultTransport
+		if settings.TlsConfig() != nil {
+			transport = &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: settings.TlsConfig(),
+			}
+		}
+		httpClient = &http.Client{Transport: transport, Timeout: settings.OperationTimeout()}
+	} else if httpClient.Timeout == 0 {
+		withTimeout := *httpClient
+		withTimeout.Timeout = settings.OperationTimeout()
+		httpClient = &withTimeout
+	}
+
+	var endpointDiscoverer internal.EndpointDiscoverer
+	if cs := settings.ClusterSettings(); cs != nil {
+		endpointDiscoverer = internal.NewClusterDnsEndPointDiscoverer(cs.ClusterDns(), cs.MaxDiscoverAttempts(),
+			cs.ExternalGossipPort(), cs.GossipSeeds(), cs.GossipTimeout())
+	}
+
 	return &Manager{
-		client:       newClient(operationTimeout),
-		httpEndpoint: httpEndpoint,
+		client:             newClient(httpClient, settings.UseHttps()),
+		httpEndpoint:       settings.HttpEndpoint(),
+		endpointDiscoverer: endpointDiscoverer,
+	}
+}
+
+// With cluster discovery, the master is looked up for every command so that commands follow failovers
+func (m *Manager) send(command func(addr *net.TCPAddr) *tasks.Task) *tasks.Task {
+	if m.endpointDiscoverer == nil {
+		return command(m.httpEndpoint)
+	}
+	return tasks.New(func() (interface{}, error) {
+		addr, err := m.discoverHttpEndpoint()
+		if err != nil {
+			return nil, err
+		}
+		task := command(addr)
+		return task.Result(), task.Error()
+	})
+}
+
+func (m *Manager) discoverHttpEndpoint() (*net.TCPAddr, error) {
+	m.discoverLock.Lock()
+	defer m.discoverLock.Unlock()
+
+	task := m.endpointDiscoverer.DiscoverAsync(nil)
+	if err := task.Error(); err != nil {
+		return nil, err
+	}
+	addr, ok := task.Result().(*internal.NodeEndpoints).HttpEndpoint().(*net.TCPAddr)
+	if !ok || addr == nil {
+		return nil, errors.New("discovered node has no http endpoint")
 	}
+	return addr, nil
 }
 
 // Task.Result() returns nil
@@ -32,7 +102,9 @@ func (m *Manager) EnableAsync(name string, userCredentials *cli.UserCredentials)
 		panic("name must be present")
 	}
 
-	return m.client.Enable(m.httpEndpoint, name, userCredentials)
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.Enable(addr, name, userCredentials)
+	})
 }
 
 // Task.Result() returns nil
@@ -41,7 +113,9 @@ func (m *Manager) DisableAsync(name string, userCredentials *cli.UserCredentials
 		panic("name must be present")
 	}
 
-	return m.client.Disable(m.httpEndpoint, name, userCredentials)
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.Disable(addr, name, userCredentials)
+	})
 }
 
 // Task.Result() returns nil
@@ -50,7 +124,20 @@ func (m *Manager) AbortAsync(name string, userCredentials *cli.UserCredentials)
 		panic("name must be present")
 	}
 
-	return m.client.Abort(m.httpEndpoint, name, userCredentials)
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.Abort(addr, name, userCredentials)
+	})
+}
+
+// Task.Result() returns nil
+func (m *Manager) ResetAsync(name string, userCredentials *cli.UserCredentials) *tasks.Task {
+	if name == "" {
+		panic("name must be present")
+	}
+
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.Reset(addr, name, userCredentials)
+	})
 }
 
 // Task.Result() returns nil
@@ -59,7 +146,9 @@ func (m *Manager) CreateOneTimeAsync(query string, userCredentials *cli.UserCred
 		panic("query must be present")
 	}
 
-	return m.client.CreateOneTime(m.httpEndpoint, query, userCredentials)
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.CreateOneTime(addr, query, userCredentials)
+	})
 }
 
 // Task.Result() returns nil
@@ -71,7 +160,9 @@ func (m *Manager) CreateTransientAsync(name string, query string, userCredential
 		panic("query must be present")
 	}
 
-	return m.client.CreateTransient(m.httpEndpoint, name, query, userCredentials)
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.CreateTransient(addr, name, query, true, userCredentials)
+	})
 }
 
 // Task.Result() returns nil
@@ -88,22 +179,30 @@ func (m *Manager) CreateContinuousAsync(
 		panic("query must be present")
 	}
 
-	return m.client.CreateContinuous(m.httpEndpoint, name, query, trackEmittedStreams, userCredentials)
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.CreateContinuous(addr, name, query, true, trackEmittedStreams, true, userCredentials)
+	})
 }
 
 // Task.Result() returns []*projections.ProjectionDetails
 func (m *Manager) ListAllAsync(userCredentials *cli.UserCredentials) *tasks.Task {
-	return m.client.ListAll(m.httpEndpoint, userCredentials)
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.ListAll(addr, userCredentials)
+	})
 }
 
 // Task.Result() returns []*projections.ProjectionDetails
 func (m *Manager) ListOneTimeAsync(userCredentials *cli.UserCredentials) *tasks.Task {
-	return m.client.ListOneTime(m.httpEndpoint, userCredentials)
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.ListOneTime(addr, userCredentials)
+	})
 }
 
 // Task.Result() returns []*projections.ProjectionDetails
 func (m *Manager) ListContinuousAsync(userCredentials *cli.UserCredentials) *tasks.Task {
-	return m.client.ListContinuous(m.httpEndpoint, userCredentials)
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.ListContinuous(addr, userCredentials)
+	})
 }
 
 // Task.Result() returns a string
@@ -112,7 +211,9 @@ func (m *Manager) GetStatusAsync(name string, userCredentials *cli.UserCredentia
 		panic("name must be present")
 	}
 
-	return m.client.GetStatus(m.httpEndpoint, name, userCredentials)
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.GetStatus(addr, name, userCredentials)
+	})
 }
 
 // Task.Result() returns a string
@@ -121,7 +222,9 @@ func (m *Manager) GetStateAsync(name string, userCredentials *cli.UserCredential
 		panic("name must be present")
 	}
 
-	return m.client.GetState(m.httpEndpoint, name, userCredentials)
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.GetState(addr, name, userCredentials)
+	})
 }
 
 // Task.Result() returns a string
@@ -137,7 +240,9 @@ func (m *Manager) GetPartitionStateAsync(
 		panic("partitionId must be present")
 	}
 
-	return m.client.GetPartitionStateAsync(m.httpEndpoint, name, partitionId, userCredentials)
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.GetPartitionStateAsync(addr, name, partitionId, userCredentials)
+	})
 }
 
 // Task.Result() returns a string
@@ -146,7 +251,9 @@ func (m *Manager) GetResultAsync(name string, userCredentials *cli.UserCredentia
 		panic("name must be present")
 	}
 
-	return m.client.GetResult(m.httpEndpoint, name, userCredentials)
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.GetResult(addr, name, userCredentials)
+	})
 }
 
 // Task.Result() returns a string
@@ -162,16 +269,31 @@ func (m *Manager) GetPartitionResultAsync(
 		panic("partitionId must be present")
 	}
 
-	return m.client.GetPartitionResultAsync(m.httpEndpoint, name, partitionId, userCredentials)
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.GetPartitionResultAsync(addr, name, partitionId, userCredentials)
+	})
 }
 
-// Task.Result() returns a string
+// Task.Result() returns *projections.ProjectionStatistics
 func (m *Manager) GetStatisticsAsync(name string, userCredentials *cli.UserCredentials) *tasks.Task {
 	if name == "" {
 		panic("name must be present")
 	}
 
-	return m.client.GetStatistics(m.httpEndpoint, name, userCredentials)
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.GetStatistics(addr, name, userCredentials)
+	})
+}
+
+// Task.Result() returns []string, the partitions of a partitioned projection in the order they were created
+func (m *Manager) ListPartitionsAsync(name string, userCredentials *cli.UserCredentials) *tasks.Task {
+	if name == "" {
+		panic("name must be present")
+	}
+
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.ListPartitions(addr, name, userCredentials)
+	})
 }
 
 // Task.Result() returns a string
@@ -180,7 +302,9 @@ func (m *Manager) GetQueryAsync(name string, userCredentials *cli.UserCredential
 		panic("name must be present")
 	}
 
-	return m.client.GetQuery(m.httpEndpoint, name, userCredentials)
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.GetQuery(addr, name, userCredentials)
+	})
 }
 
 // Task.Result() returns nil
@@ -192,7 +316,58 @@ func (m *Manager) UpdateQueryAsync(name string, query string, userCredentials *c
 		panic("query must be present")
 	}
 
-	return m.client.GetQuery(m.httpEndpoint, name, userCredentials)
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.UpdateQuery(addr, name, query, userCredentials)
+	})
+}
+
+// Same as UpdateQueryAsync, but also enables or disables emitting events
+// Task.Result() returns nil
+func (m *Manager) UpdateQueryEmitAsync(
+	name string,
+	query string,
+	emitEnabled bool,
+	userCredentials *cli.UserCredentials,
+) *tasks.Task {
+	if name == "" {
+		panic("name must be present")
+	}
+	if query == "" {
+		panic("query must be present")
+	}
+
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.UpdateQueryEmit(addr, name, query, emitEnabled, userCredentials)
+	})
+}
+
+// Task.Result() returns *projections.ProjectionConfig
+func (m *Manager) GetConfigAsync(name string, userCredentials *cli.UserCredentials) *tasks.Task {
+	if name == "" {
+		panic("name must be present")
+	}
+
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.GetConfig(addr, name, userCredentials)
+	})
+}
+
+// Task.Result() returns nil
+func (m *Manager) UpdateConfigAsync(
+	name string,
+	config *ProjectionConfig,
+	userCredentials *cli.UserCredentials,
+) *tasks.Task {
+	if name == "" {
+		panic("name must be present")
+	}
+	if config == nil {
+		panic("config is nil")
+	}
+
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.UpdateConfig(addr, name, config, userCredentials)
+	})
 }
 
 // Task.Result() returns nil
@@ -205,5 +380,7 @@ func (m *Manager) DeleteQueryAsync(
 		panic("name must be present")
 	}
 
-	return m.client.Delete(m.httpEndpoint, name, deleteEmittedStreams, userCredentials)
+	return m.send(func(addr *net.TCPAddr) *tasks.Task {
+		return m.client.Delete(addr, name, deleteEmittedStreams, userCredentials)
+	})
 }
@@ -0,0 +1,93 @@
+package projections
+
+import (
+	"crypto/tls"
+	cli "github.com/jdextraze/go-gesclient/client"
+	"net"
+	"net/http"
+	"time"
+)
+
+type ManagerSettings struct {
+	httpEndpoint     *net.TCPAddr
+	operationTimeout time.Duration
+	useHttps         bool
+	tlsConfig        *tls.Config
+	httpClient       *http.Client
+	clusterSettings  *cli.ClusterSettings
+}
+
+func (s *ManagerSettings) HttpEndpoint() *net.TCPAddr { return s.httpEndpoint }
+
+func (s *ManagerSettings) OperationTimeout() time.Duration { return s.operationTimeout }
+
+func (s *ManagerSettings) UseHttps() bool { return s.useHttps }
+
+func (s *ManagerSettings) TlsConfig() *tls.Config { return s.tlsConfig }
+
+func (s *ManagerSettings) HttpClient() *http.Client { return s.httpClient }
+
+func (s *ManagerSettings) ClusterSettings() *cli.ClusterSettings { return s.clusterSettings }
+
+type ManagerSettingsBuilder struct {
+	httpEndpoint     *net.TCPAddr
+	operationTimeout time.Duration
+	useHttps         bool
+	tlsConfig        *tls.Config
+	httpClient       *http.Client
+	clusterSettings  *cli.ClusterSettings
+}
+
+func CreateManagerSettings() *ManagerSettingsBuilder {
+	return &ManagerSettingsBuilder{
+		operationTimeout: cli.DefaultOperationTimeout,
+	}
+}
+
+func (b *ManagerSettingsBuilder) SetHttpEndpoint(httpEndpoint *net.TCPAddr) *ManagerSettingsBuilder {
+	b.httpEndpoint = httpEndpoint
+	return b
+}
+
+func (b *ManagerSettingsBuilder) SetOperationTimeoutTo(timeout time.Duration) *ManagerSettingsBuilder {
+	b.operationTimeout = timeout
+	return b
+}
+
+// A nil config verifies the server certificate against the system roots
+func (b *ManagerSettingsBuilder) UseHttps(config *tls.Config) *ManagerSettingsBuilder {
+	b.useHttps = true
+	b.tlsConfig = config
+	return b
+}
+
+// The client is used as is, so its transport must carry any TLS configuration. The operation timeout is applied
+// only when the client has no timeout of its own.
+func (b *ManagerSettingsBuilder) SetHttpClient(httpClient *http.Client) *ManagerSettingsBuilder {
+	b.httpClient = httpClient
+	return b
+}
+
+// Commands are sent to the HTTP endpoint of the current master, as found through gossip, instead of the configured
+// HTTP endpoint
+func (b *ManagerSettingsBuilder) DiscoverMaster(clusterSettings *cli.ClusterSettings) *ManagerSettingsBuilder {
+	b.clusterSettings = clusterSettings
+	return b
+}
+
+func (b *ManagerSettingsBuilder) Build() *ManagerSettings {
+	if b.httpEndpoint == nil && b.clusterSettings == nil {
+		panic("httpEndpoint or clusterSettings must be present")
+	}
+	if b.operationTimeout < 0 {
+		panic("operationTimeout must be positive")
+	}
+	return &ManagerSettings{
+		httpEndpoint:     b.httpEndpoint,
+		operationTimeout: b.operationTimeout,
+		useHttps:         b.useHttps,
+		tlsConfig:        b.tlsConfig,
+		httpClient:       b.httpClient,
+		clusterSettings:  b.clusterSettings,
+	}
+}
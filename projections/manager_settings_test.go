@@ -0,0 +1,107 @@
+package projections_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/jdextraze/go-gesclient/client"
+	"github.com/jdextraze/go-gesclient/projections"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func resolveAddr(t *testing.T, server *httptest.Server) *net.TCPAddr {
+	addr, err := net.ResolveTCPAddr("tcp", server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return addr
+}
+
+func TestManager_Https(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"count":5}`)
+	}))
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	manager := projections.NewManagerFromSettings(projections.CreateManagerSettings().
+		SetHttpEndpoint(resolveAddr(t, server)).
+		UseHttps(&tls.Config{RootCAs: roots}).
+		Build())
+
+	var state struct{ Count int }
+	if err := projections.Decode(manager.GetStateAsync("counter", nil), &state); err != nil || state.Count != 5 {
+		t.Errorf("Unexpected state over https: %+v %v", state, err)
+	}
+
+	untrusted := projections.NewManagerFromSettings(projections.CreateManagerSettings().
+		SetHttpEndpoint(resolveAddr(t, server)).
+		UseHttps(nil).
+		Build())
+	if err := untrusted.GetStateAsync("counter", nil).Error(); err == nil {
+		t.Error("Expected an untrusted certificate to be rejected")
+	}
+}
+
+func TestManager_OperationTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	manager := projections.NewManager(resolveAddr(t, server), 50*time.Millisecond)
+	if err := manager.EnableAsync("counter", nil).Error(); err == nil {
+		t.Error("Expected the command to time out")
+	}
+}
+
+func TestManager_DiscoverMaster(t *testing.T) {
+	enabled := make(chan string, 2)
+	newNode := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			enabled <- name
+		}))
+	}
+	master := newNode("master")
+	defer master.Close()
+	slave := newNode("slave")
+	defer slave.Close()
+
+	members := map[string]*httptest.Server{"Slave": slave, "Master": master}
+	gossip := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gossip" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"members":[`)
+		i := 0
+		for state, node := range members {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			addr := resolveAddr(t, node)
+			fmt.Fprintf(w, `{"state":"%s","isAlive":true,"externalTcpIp":"127.0.0.1","externalTcpPort":1113,`+
+				`"externalHttpIp":"%s","externalHttpPort":%d}`, state, addr.IP, addr.Port)
+			i++
+		}
+		fmt.Fprint(w, `]}`)
+	}))
+	defer gossip.Close()
+
+	seeds := []*client.GossipSeed{client.NewGossipSeed(resolveAddr(t, gossip), "")}
+	manager := projections.NewManagerFromSettings(projections.CreateManagerSettings().
+		DiscoverMaster(client.NewClusterSettings("", 1, 0, seeds, time.Second)).
+		Build())
+
+	if err := manager.EnableAsync("counter", nil).Error(); err != nil {
+		t.Fatalf("EnableAsync failed: %v", err)
+	}
+	if node := <-enabled; node != "master" {
+		t.Errorf("Command was sent to the %s", node)
+	}
+}
@@ -0,0 +1,224 @@
+package projections_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jdextraze/go-gesclient/client"
+	"github.com/jdextraze/go-gesclient/projections"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestManager(t *testing.T, handler http.HandlerFunc) (*projections.Manager, func()) {
+	server := httptest.NewServer(handler)
+	addr, err := net.ResolveTCPAddr("tcp", server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return projections.NewManager(addr, time.Second), server.Close
+}
+
+func TestManager_Decode(t *testing.T) {
+	manager, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/projection/counter/state" && r.URL.Query().Get("partition") == "a b&c":
+			fmt.Fprint(w, `{"count":3}`)
+		case r.URL.Path == "/projection/counter/state":
+			fmt.Fprint(w, `{"count":5}`)
+		case r.URL.Path == "/projection/counter/result":
+			fmt.Fprint(w, ``)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer closeServer()
+
+	var state struct{ Count int }
+	if err := projections.Decode(manager.GetStateAsync("counter", nil), &state); err != nil || state.Count != 5 {
+		t.Errorf("State doesn't match: %+v, %v", state, err)
+	}
+	if err := projections.Decode(manager.GetPartitionStateAsync("counter", "a b&c", nil), &state); err != nil ||
+		state.Count != 3 {
+		t.Errorf("Partition state doesn't match: %+v, %v", state, err)
+	}
+	if err := projections.Decode(manager.GetResultAsync("counter", nil), &state); err != nil || state.Count != 3 {
+		t.Errorf("An empty result should leave the value untouched: %+v, %v", state, err)
+	}
+	if err := projections.Decode(manager.GetStateAsync("missing", nil), &state); err == nil {
+		t.Error("Decode should fail when the request fails")
+	}
+}
+
+func TestManager_GetStatisticsAsync(t *testing.T) {
+	manager, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"projections":[{"name":"counter","status":"Running","mode":"Continuous",`+
+			`"eventsProcessedAfterRestart":12,"progress":100.0,"coreProcessingTime":42}]}`)
+	})
+	defer closeServer()
+
+	task := manager.GetStatisticsAsync("counter", nil)
+	if err := task.Error(); err != nil {
+		t.Fatalf("GetStatisticsAsync failed: %v", err)
+	}
+	stats := task.Result().(*projections.ProjectionStatistics)
+	if stats.Name != "counter" || stats.Status != "Running" || stats.EventsProcessedAfterRestart != 12 ||
+		stats.Progress != 100 || stats.CoreProcessingTime != 42 {
+		t.Errorf("Statistics don't match: %+v", stats)
+	}
+}
+
+func TestManager_ListPartitionsAsync(t *testing.T) {
+	manager, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/streams/$projections-counter-partitions/0/forward/100":
+			var entries []string
+			for i := 99; i >= 0; i-- {
+				entries = append(entries, fmt.Sprintf(`{"eventNumber":%d,"data":"p%d"}`, i, i%60))
+			}
+			fmt.Fprintf(w, `{"entries":[%s]}`, strings.Join(entries, ","))
+		case "/streams/$projections-counter-partitions/100/forward/100":
+			fmt.Fprint(w, `{"entries":[{"eventNumber":100,"data":"last"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer closeServer()
+
+	task := manager.ListPartitionsAsync("counter", nil)
+	if err := task.Error(); err != nil {
+		t.Fatalf("ListPartitionsAsync failed: %v", err)
+	}
+	partitions := task.Result().([]string)
+	if len(partitions) != 61 || partitions[0] != "p0" || partitions[59] != "p59" || partitions[60] != "last" {
+		t.Errorf("Partitions don't match: %v", partitions)
+	}
+
+	task = manager.ListPartitionsAsync("other", nil)
+	if err := task.Error(); err != nil || len(task.Result().([]string)) != 0 {
+		t.Errorf("A projection without partitions should have no partitions: %v, %v", task.Result(), err)
+	}
+}
+
+func TestManager_EscapesNames(t *testing.T) {
+	paths := make(chan string, 1)
+	manager, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		paths <- r.URL.EscapedPath() + "?" + r.URL.RawQuery
+	})
+	defer closeServer()
+
+	manager.DeleteQueryAsync("a/b", true, nil).Wait()
+	if path := <-paths; path != "/projection/a%2Fb?deleteEmittedStreams=true" {
+		t.Errorf("Path doesn't match: %s", path)
+	}
+}
+
+func TestManager_UpdateQueryAsync(t *testing.T) {
+	requests := make(chan string, 2)
+	manager, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests <- fmt.Sprintf("%s %s?%s %s", r.Method, r.URL.Path, r.URL.RawQuery, body)
+	})
+	defer closeServer()
+
+	if err := manager.UpdateQueryAsync("counter", "fromAll()", nil).Error(); err != nil {
+		t.Fatalf("UpdateQueryAsync failed: %v", err)
+	}
+	if r := <-requests; r != "PUT /projection/counter/query?type=JS fromAll()" {
+		t.Errorf("Request doesn't match: %s", r)
+	}
+	if err := manager.UpdateQueryEmitAsync("counter", "fromAll()", true, nil).Error(); err != nil {
+		t.Fatalf("UpdateQueryEmitAsync failed: %v", err)
+	}
+	if r := <-requests; r != "PUT /projection/counter/query?type=JS&emit=1 fromAll()" {
+		t.Errorf("Request doesn't match: %s", r)
+	}
+}
+
+func TestManager_ResetAsync(t *testing.T) {
+	requests := make(chan string, 1)
+	manager, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		requests <- r.Method + " " + r.URL.Path
+	})
+	defer closeServer()
+
+	if err := manager.ResetAsync("counter", nil).Error(); err != nil {
+		t.Fatalf("ResetAsync failed: %v", err)
+	}
+	if r := <-requests; r != "POST /projection/counter/command/reset" {
+		t.Errorf("Request doesn't match: %s", r)
+	}
+}
+
+func TestManager_Config(t *testing.T) {
+	var stored []byte
+	manager, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projection/counter/config" {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			stored, _ = ioutil.ReadAll(r.Body)
+		case http.MethodGet:
+			w.Write(stored)
+		}
+	})
+	defer closeServer()
+
+	config := &projections.ProjectionConfig{
+		EmitEnabled:            true,
+		TrackEmittedStreams:    true,
+		CheckpointAfterMs:      1000,
+		PendingEventsThreshold: 5000,
+	}
+	if err := manager.UpdateConfigAsync("counter", config, nil).Error(); err != nil {
+		t.Fatalf("UpdateConfigAsync failed: %v", err)
+	}
+	task := manager.GetConfigAsync("counter", nil)
+	if err := task.Error(); err != nil {
+		t.Fatalf("GetConfigAsync failed: %v", err)
+	}
+	if result := task.Result().(*projections.ProjectionConfig); *result != *config {
+		t.Errorf("Config doesn't match: %+v != %+v", result, config)
+	}
+}
+
+func TestManager_HttpErrors(t *testing.T) {
+	manager, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projection/missing":
+			http.Error(w, "projection not found", http.StatusNotFound)
+		case "/projection/secret/command/enable":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/projections/continuous":
+			w.WriteHeader(http.StatusConflict)
+		}
+	})
+	defer closeServer()
+
+	err := manager.GetStatusAsync("missing", nil).Error()
+	if !errors.Is(err, client.NotFound) {
+		t.Errorf("Expected NotFound: %v", err)
+	}
+	httpErr, ok := err.(*client.HttpError)
+	if !ok {
+		t.Fatalf("Expected an HttpError: %T", err)
+	}
+	if httpErr.StatusCode != http.StatusNotFound || httpErr.Method != http.MethodGet ||
+		!strings.HasSuffix(httpErr.URL, "/projection/missing") || httpErr.Body != "projection not found\n" {
+		t.Errorf("HttpError doesn't match: %+v", httpErr)
+	}
+
+	if err := manager.EnableAsync("secret", nil).Error(); !errors.Is(err, client.AccessDenied) {
+		t.Errorf("Expected AccessDenied: %v", err)
+	}
+	if err := manager.CreateContinuousAsync("counter", "fromAll()", false, nil).Error(); !errors.Is(err,
+		client.Conflict) {
+		t.Errorf("Expected Conflict: %v", err)
+	}
+}
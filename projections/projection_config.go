@@ -0,0 +1,13 @@
+package projections
+
+// Configuration of a projection. The projection must be stopped to update it.
+type ProjectionConfig struct {
+	EmitEnabled                       bool `json:"emitEnabled"`
+	TrackEmittedStreams               bool `json:"trackEmittedStreams"`
+	CheckpointAfterMs                 int  `json:"checkpointAfterMs"`
+	CheckpointHandledThreshold        int  `json:"checkpointHandledThreshold"`
+	CheckpointUnhandledBytesThreshold int  `json:"checkpointUnhandledBytesThreshold"`
+	PendingEventsThreshold            int  `json:"pendingEventsThreshold"`
+	MaxWriteBatchLength               int  `json:"maxWriteBatchLength"`
+	MaxAllowedWritesInFlight          int  `json:"maxAllowedWritesInFlight"`
+}
@@ -0,0 +1,91 @@
+package projections
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	ProjectionMode_Continuous = "Continuous"
+	ProjectionMode_Transient  = "Transient"
+)
+
+// Desired state of a projection, see Reconciler. Only continuous projections can emit and track emitted streams.
+type ProjectionDefinition struct {
+	Name         string
+	Query        string
+	Mode         string
+	Emit         bool
+	TrackEmitted bool
+	Enabled      bool
+}
+
+func NewProjectionDefinition(name string, query string) *ProjectionDefinition {
+	return &ProjectionDefinition{
+		Name:    name,
+		Query:   query,
+		Mode:    ProjectionMode_Continuous,
+		Enabled: true,
+	}
+}
+
+type projectionDefinitionOptions struct {
+	Mode         *string `json:"mode"`
+	Emit         *bool   `json:"emit"`
+	TrackEmitted *bool   `json:"trackEmitted"`
+	Enabled      *bool   `json:"enabled"`
+}
+
+// Loads a definition for every <name>.js file of dir. Options are read from an optional <name>.json file, for example
+// {"mode": "Continuous", "emit": true, "trackEmitted": false, "enabled": true}. Missing options default to an enabled
+// continuous projection that doesn't emit.
+func LoadProjectionDefinitions(dir string) ([]*ProjectionDefinition, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.js"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	definitions := make([]*ProjectionDefinition, 0, len(paths))
+	for _, path := range paths {
+		query, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		d := NewProjectionDefinition(strings.TrimSuffix(filepath.Base(path), ".js"), string(query))
+		options, err := ioutil.ReadFile(strings.TrimSuffix(path, ".js") + ".json")
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if err == nil {
+			if err := d.applyOptions(options); err != nil {
+				return nil, err
+			}
+		}
+		definitions = append(definitions, d)
+	}
+	return definitions, nil
+}
+
+func (d *ProjectionDefinition) applyOptions(data []byte) error {
+	o := projectionDefinitionOptions{}
+	if err := json.Unmarshal(data, &o); err != nil {
+		return err
+	}
+	if o.Mode != nil {
+		d.Mode = *o.Mode
+	}
+	if o.Emit != nil {
+		d.Emit = *o.Emit
+	}
+	if o.TrackEmitted != nil {
+		d.TrackEmitted = *o.TrackEmitted
+	}
+	if o.Enabled != nil {
+		d.Enabled = *o.Enabled
+	}
+	return nil
+}
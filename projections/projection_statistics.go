@@ -0,0 +1,10 @@
+package projections
+
+// Statistics of a projection, as returned by GetStatisticsAsync
+type ProjectionStatistics struct {
+	ProjectionDetails
+}
+
+type statisticsResult struct {
+	Projections []*ProjectionStatistics
+}
@@ -0,0 +1,256 @@
+package projections
+
+import (
+	"fmt"
+	cli "github.com/jdextraze/go-gesclient/client"
+	"github.com/jdextraze/go-gesclient/tasks"
+	"net"
+	"strings"
+)
+
+type ReconcileActionType int
+
+const (
+	ReconcileActionType_Create ReconcileActionType = iota
+	ReconcileActionType_UpdateQuery
+	ReconcileActionType_UpdateConfig
+	ReconcileActionType_Enable
+	ReconcileActionType_Disable
+	ReconcileActionType_Delete
+)
+
+var reconcileActionTypeValues = []string{
+	"Create",
+	"UpdateQuery",
+	"UpdateConfig",
+	"Enable",
+	"Disable",
+	"Delete",
+}
+
+func (t ReconcileActionType) String() string {
+	return reconcileActionTypeValues[t]
+}
+
+type ReconcileAction struct {
+	Type       ReconcileActionType
+	Name       string
+	Definition *ProjectionDefinition
+	Reason     string
+}
+
+func (a *ReconcileAction) String() string {
+	return fmt.Sprintf("%s %s: %s", a.Type, a.Name, a.Reason)
+}
+
+// Actions in the order they are applied
+type ReconcilePlan []*ReconcileAction
+
+func (p ReconcilePlan) String() string {
+	lines := make([]string, len(p))
+	for i, a := range p {
+		lines[i] = a.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Converges the projections of the server to a desired set of projection definitions. System projections, which
+// names start with $, are never changed.
+type Reconciler struct {
+	manager         *Manager
+	deleteUnknown   bool
+	userCredentials *cli.UserCredentials
+}
+
+// When deleteUnknown is true, continuous projections that are not part of the desired set are deleted. Their emitted
+// streams are kept.
+func NewReconciler(manager *Manager, deleteUnknown bool, userCredentials *cli.UserCredentials) *Reconciler {
+	if manager == nil {
+		panic("manager is nil")
+	}
+
+	return &Reconciler{
+		manager:         manager,
+		deleteUnknown:   deleteUnknown,
+		userCredentials: userCredentials,
+	}
+}
+
+// Computes the plan and applies it, unless dryRun is true
+func (r *Reconciler) Sync(desired []*ProjectionDefinition, dryRun bool) (ReconcilePlan, error) {
+	plan, err := r.Plan(desired)
+	if err != nil || dryRun {
+		return plan, err
+	}
+	return plan, r.Apply(plan)
+}
+
+func (r *Reconciler) Plan(desired []*ProjectionDefinition) (ReconcilePlan, error) {
+	names := map[string]bool{}
+	for _, d := range desired {
+		if err := validateDefinition(d); err != nil {
+			return nil, err
+		}
+		if names[d.Name] {
+			return nil, fmt.Errorf("projection '%s' is defined more than once", d.Name)
+		}
+		names[d.Name] = true
+	}
+
+	task := r.manager.ListAllAsync(r.userCredentials)
+	if err := task.Error(); err != nil {
+		return nil, err
+	}
+	existing := map[string]*ProjectionDetails{}
+	for _, p := range task.Result().([]*ProjectionDetails) {
+		existing[p.Name] = p
+	}
+
+	var plan ReconcilePlan
+	for _, d := range desired {
+		actions, err := r.planDefinition(d, existing[d.Name])
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, actions...)
+	}
+	if r.deleteUnknown {
+		for _, p := range task.Result().([]*ProjectionDetails) {
+			if !names[p.Name] && !isSystemProjection(p.Name) && p.Mode == ProjectionMode_Continuous {
+				plan = append(plan, deleteActions(p, "not defined")...)
+			}
+		}
+	}
+	return plan, nil
+}
+
+func (r *Reconciler) planDefinition(d *ProjectionDefinition, p *ProjectionDetails) (ReconcilePlan, error) {
+	if p == nil {
+		return createActions(d, "not found"), nil
+	}
+	if p.Mode != d.Mode {
+		reason := fmt.Sprintf("mode changed from %s to %s", p.Mode, d.Mode)
+		return append(deleteActions(p, reason), createActions(d, reason)...), nil
+	}
+
+	queryTask := r.manager.GetQueryAsync(d.Name, r.userCredentials)
+	if err := queryTask.Error(); err != nil {
+		return nil, err
+	}
+	configTask := r.manager.GetConfigAsync(d.Name, r.userCredentials)
+	if err := configTask.Error(); err != nil {
+		return nil, err
+	}
+	query := queryTask.Result().(string)
+	config := configTask.Result().(*ProjectionConfig)
+
+	var plan ReconcilePlan
+	if strings.TrimSpace(query) != strings.TrimSpace(d.Query) {
+		plan = append(plan, &ReconcileAction{ReconcileActionType_UpdateQuery, d.Name, d, "query changed"})
+	} else if config.EmitEnabled != d.Emit {
+		plan = append(plan, &ReconcileAction{ReconcileActionType_UpdateQuery, d.Name, d,
+			fmt.Sprintf("emit changed to %t", d.Emit)})
+	}
+	running, status := isRunning(p), p.Status
+	if config.TrackEmittedStreams != d.TrackEmitted {
+		if running {
+			plan = append(plan, &ReconcileAction{ReconcileActionType_Disable, d.Name, d,
+				"required to update the config"})
+			running, status = false, "Stopped"
+		}
+		plan = append(plan, &ReconcileAction{ReconcileActionType_UpdateConfig, d.Name, d,
+			fmt.Sprintf("trackEmitted changed to %t", d.TrackEmitted)})
+	}
+	if d.Enabled && !running {
+		plan = append(plan, &ReconcileAction{ReconcileActionType_Enable, d.Name, d, "status is " + status})
+	} else if !d.Enabled && running {
+		plan = append(plan, &ReconcileAction{ReconcileActionType_Disable, d.Name, d, "status is " + status})
+	}
+	return plan, nil
+}
+
+// Stops at the first action that fails
+func (r *Reconciler) Apply(plan ReconcilePlan) error {
+	for _, a := range plan {
+		if err := r.apply(a); err != nil {
+			return fmt.Errorf("%s failed: %v", a, err)
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) apply(a *ReconcileAction) error {
+	m := r.manager
+	switch a.Type {
+	case ReconcileActionType_Create:
+		d := a.Definition
+		return m.send(func(addr *net.TCPAddr) *tasks.Task {
+			if d.Mode == ProjectionMode_Transient {
+				return m.client.CreateTransient(addr, d.Name, d.Query, d.Enabled, r.userCredentials)
+			}
+			return m.client.CreateContinuous(addr, d.Name, d.Query, d.Emit, d.TrackEmitted, d.Enabled,
+				r.userCredentials)
+		}).Error()
+	case ReconcileActionType_UpdateQuery:
+		return m.UpdateQueryEmitAsync(a.Name, a.Definition.Query, a.Definition.Emit, r.userCredentials).Error()
+	case ReconcileActionType_UpdateConfig:
+		task := m.GetConfigAsync(a.Name, r.userCredentials)
+		if err := task.Error(); err != nil {
+			return err
+		}
+		config := task.Result().(*ProjectionConfig)
+		config.EmitEnabled = a.Definition.Emit
+		config.TrackEmittedStreams = a.Definition.TrackEmitted
+		return m.UpdateConfigAsync(a.Name, config, r.userCredentials).Error()
+	case ReconcileActionType_Enable:
+		return m.EnableAsync(a.Name, r.userCredentials).Error()
+	case ReconcileActionType_Disable:
+		return m.DisableAsync(a.Name, r.userCredentials).Error()
+	case ReconcileActionType_Delete:
+		return m.DeleteQueryAsync(a.Name, false, r.userCredentials).Error()
+	default:
+		return fmt.Errorf("unknown action type: %d", a.Type)
+	}
+}
+
+// Disabled projections are created stopped, so they never process events
+func createActions(d *ProjectionDefinition, reason string) ReconcilePlan {
+	return ReconcilePlan{&ReconcileAction{ReconcileActionType_Create, d.Name, d, reason}}
+}
+
+// The server only deletes stopped projections
+func deleteActions(p *ProjectionDetails, reason string) ReconcilePlan {
+	var plan ReconcilePlan
+	if isRunning(p) {
+		plan = append(plan, &ReconcileAction{ReconcileActionType_Disable, p.Name, nil, "required to delete"})
+	}
+	return append(plan, &ReconcileAction{ReconcileActionType_Delete, p.Name, nil, reason})
+}
+
+func validateDefinition(d *ProjectionDefinition) error {
+	if d.Name == "" {
+		return fmt.Errorf("projection name must be present")
+	}
+	if isSystemProjection(d.Name) {
+		return fmt.Errorf("system projection '%s' cannot be reconciled", d.Name)
+	}
+	if d.Query == "" {
+		return fmt.Errorf("query of projection '%s' must be present", d.Name)
+	}
+	if d.Mode != ProjectionMode_Continuous && d.Mode != ProjectionMode_Transient {
+		return fmt.Errorf("mode of projection '%s' must be %s or %s", d.Name, ProjectionMode_Continuous,
+			ProjectionMode_Transient)
+	}
+	if d.Mode == ProjectionMode_Transient && (d.Emit || d.TrackEmitted) {
+		return fmt.Errorf("transient projection '%s' cannot emit or track emitted streams", d.Name)
+	}
+	return nil
+}
+
+func isSystemProjection(name string) bool {
+	return strings.HasPrefix(name, "$")
+}
+
+func isRunning(p *ProjectionDetails) bool {
+	return strings.HasPrefix(p.Status, "Running")
+}
@@ -0,0 +1,183 @@
+package projections_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/jdextraze/go-gesclient/projections"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type fakeProjection struct {
+	Name   string `json:"name"`
+	Mode   string `json:"mode"`
+	Status string `json:"status"`
+	query  string
+	config projections.ProjectionConfig
+}
+
+// Serves the subset of the projections HTTP API used by the reconciler
+func fakeProjectionsHandler(existing ...*fakeProjection) http.HandlerFunc {
+	var lock sync.Mutex
+	byName := map[string]*fakeProjection{}
+	for _, p := range existing {
+		byName[p.Name] = p
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		body, _ := ioutil.ReadAll(r.Body)
+		q := r.URL.Query()
+		if r.URL.Path == "/projections/any" {
+			list := []*fakeProjection{}
+			for _, p := range byName {
+				list = append(list, p)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"projections": list})
+			return
+		}
+		if r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/projections/") {
+			p := &fakeProjection{Name: q.Get("name"), Status: "Running", query: string(body)}
+			if q.Get("enabled") == "false" {
+				p.Status = "Stopped"
+			}
+			p.Mode = map[string]string{"continuous": "Continuous", "transient": "Transient"}[r.URL.Path[13:]]
+			p.config.EmitEnabled = q.Get("emit") == "1"
+			p.config.TrackEmittedStreams = q.Get("trackemittedstreams") == "true"
+			byName[p.Name] = p
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/projection/"), "/", 2)
+		p := byName[parts[0]]
+		if p == nil {
+			http.NotFound(w, r)
+			return
+		}
+		action := r.Method
+		if len(parts) > 1 {
+			action += " " + parts[1]
+		}
+		switch action {
+		case "GET query":
+			fmt.Fprint(w, p.query)
+		case "PUT query":
+			p.query = string(body)
+			p.config.EmitEnabled = q.Get("emit") == "1"
+		case "GET config":
+			json.NewEncoder(w).Encode(p.config)
+		case "PUT config", "DELETE":
+			if p.Status == "Running" {
+				w.WriteHeader(http.StatusConflict)
+			} else if action == "DELETE" {
+				delete(byName, p.Name)
+			} else {
+				json.Unmarshal(body, &p.config)
+			}
+		case "POST command/enable":
+			p.Status = "Running"
+		case "POST command/disable":
+			p.Status = "Stopped"
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func TestReconciler_Sync(t *testing.T) {
+	manager, closeServer := newTestManager(t, fakeProjectionsHandler(
+		&fakeProjection{Name: "counter", Mode: "Continuous", Status: "Running", query: "old"},
+		&fakeProjection{Name: "legacy", Mode: "Continuous", Status: "Running", query: "legacy"},
+		&fakeProjection{Name: "stopped", Mode: "Continuous", Status: "Stopped", query: "stopped"},
+		&fakeProjection{Name: "$by_category", Mode: "Continuous", Status: "Running", query: ""},
+	))
+	defer closeServer()
+
+	counter := projections.NewProjectionDefinition("counter", "new")
+	counter.Emit = true
+	counter.TrackEmitted = true
+	created := projections.NewProjectionDefinition("created", "created")
+	created.Enabled = false
+	desired := []*projections.ProjectionDefinition{
+		counter,
+		created,
+		projections.NewProjectionDefinition("stopped", "stopped\n"),
+	}
+	reconciler := projections.NewReconciler(manager, true, nil)
+
+	expected := strings.Join([]string{
+		"UpdateQuery counter: query changed",
+		"Disable counter: required to update the config",
+		"UpdateConfig counter: trackEmitted changed to true",
+		"Enable counter: status is Stopped",
+		"Create created: not found",
+		"Enable stopped: status is Stopped",
+		"Disable legacy: required to delete",
+		"Delete legacy: not defined",
+	}, "\n")
+	for _, dryRun := range []bool{true, false} {
+		plan, err := reconciler.Sync(desired, dryRun)
+		if err != nil {
+			t.Fatalf("Sync failed: %v", err)
+		}
+		if plan.String() != expected {
+			t.Errorf("Plan doesn't match:\n%s\n!=\n%s", plan, expected)
+		}
+	}
+
+	plan, err := reconciler.Plan(desired)
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if len(plan) != 0 {
+		t.Errorf("Plan should be empty once converged:\n%s", plan)
+	}
+
+	if _, err := reconciler.Plan([]*projections.ProjectionDefinition{counter, counter}); err == nil {
+		t.Error("Plan should fail when a projection is defined twice")
+	}
+
+	transient := projections.NewProjectionDefinition("transient", "transient")
+	transient.Mode = projections.ProjectionMode_Transient
+	transient.Emit = true
+	if _, err := reconciler.Plan([]*projections.ProjectionDefinition{transient}); err == nil {
+		t.Error("Plan should fail when a transient projection emits")
+	}
+}
+
+func TestLoadProjectionDefinitions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "projections")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "counter.js"), []byte("fromAll()"), 0600)
+	ioutil.WriteFile(filepath.Join(dir, "emitter.js"), []byte("fromCategory('a')"), 0600)
+	ioutil.WriteFile(filepath.Join(dir, "emitter.json"), []byte(`{"emit":true,"enabled":false}`), 0600)
+	ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0600)
+
+	definitions, err := projections.LoadProjectionDefinitions(dir)
+	if err != nil {
+		t.Fatalf("LoadProjectionDefinitions failed: %v", err)
+	}
+	if len(definitions) != 2 {
+		t.Fatalf("Definitions length doesn't match: %d != 2", len(definitions))
+	}
+	if d := definitions[0]; d.Name != "counter" || d.Query != "fromAll()" || d.Mode != "Continuous" || d.Emit ||
+		!d.Enabled {
+		t.Errorf("Definition doesn't match: %+v", d)
+	}
+	if d := definitions[1]; d.Name != "emitter" || !d.Emit || d.Enabled || d.TrackEmitted {
+		t.Errorf("Definition doesn't match: %+v", d)
+	}
+
+	ioutil.WriteFile(filepath.Join(dir, "counter.json"), []byte(`{`), 0600)
+	if _, err := projections.LoadProjectionDefinitions(dir); err == nil {
+		t.Error("LoadProjectionDefinitions should fail when options are invalid")
+	}
+}
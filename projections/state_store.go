@@ -0,0 +1,197 @@
+package projections
+
+import (
+	"encoding/json"
+	"fmt"
+	cli "github.com/jdextraze/go-gesclient/client"
+	"github.com/satori/go.uuid"
+	"sync"
+)
+
+// Position of a local projection in its source
+type Checkpoint struct {
+	// Last processed position when reading from all
+	Position *cli.Position
+	// Last processed event number of each source stream
+	Streams map[string]int
+}
+
+type checkpointJson struct {
+	CommitPosition  *int64         `json:"commitPosition,omitempty"`
+	PreparePosition *int64         `json:"preparePosition,omitempty"`
+	Streams         map[string]int `json:"streams,omitempty"`
+}
+
+func (c *Checkpoint) MarshalJSON() ([]byte, error) {
+	data := checkpointJson{Streams: c.Streams}
+	if c.Position != nil {
+		commitPosition, preparePosition := c.Position.CommitPosition(), c.Position.PreparePosition()
+		data.CommitPosition, data.PreparePosition = &commitPosition, &preparePosition
+	}
+	return json.Marshal(data)
+}
+
+func (c *Checkpoint) UnmarshalJSON(b []byte) error {
+	data := checkpointJson{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+	c.Position = nil
+	if data.CommitPosition != nil && data.PreparePosition != nil {
+		c.Position = cli.NewPosition(*data.CommitPosition, *data.PreparePosition)
+	}
+	c.Streams = data.Streams
+	return nil
+}
+
+func (c *Checkpoint) copy() *Checkpoint {
+	streams := make(map[string]int, len(c.Streams))
+	for stream, eventNumber := range c.Streams {
+		streams[stream] = eventNumber
+	}
+	return &Checkpoint{Position: c.Position, Streams: streams}
+}
+
+// Persists the JSON encoded partition states of a local projection together with its checkpoint, so that they can
+// be restored consistently.
+type StateStore interface {
+	// Returns the last saved checkpoint and the states of all partitions, or a nil checkpoint when nothing was saved
+	Load() (*Checkpoint, map[string]json.RawMessage, error)
+	// Saves the checkpoint with the states of the partitions that changed since the previous checkpoint
+	Save(checkpoint *Checkpoint, states map[string]json.RawMessage) error
+}
+
+type memoryStateStore struct {
+	lock       sync.Mutex
+	checkpoint *Checkpoint
+	states     map[string]json.RawMessage
+}
+
+// Keeps the states in memory, so a projection using it starts over with every process
+func NewMemoryStateStore() StateStore {
+	return &memoryStateStore{states: map[string]json.RawMessage{}}
+}
+
+func (s *memoryStateStore) Load() (*Checkpoint, map[string]json.RawMessage, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.checkpoint, copyStates(s.states), nil
+}
+
+func (s *memoryStateStore) Save(checkpoint *Checkpoint, states map[string]json.RawMessage) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.checkpoint = checkpoint
+	for partition, state := range states {
+		s.states[partition] = state
+	}
+	return nil
+}
+
+const (
+	checkpointEventType = "LocalProjectionCheckpoint"
+	checkpointsToKeep   = 10
+)
+
+type streamCheckpoint struct {
+	Checkpoint *Checkpoint                `json:"checkpoint"`
+	States     map[string]json.RawMessage `json:"states"`
+}
+
+type streamStateStore struct {
+	connection      cli.Connection
+	stream          string
+	userCredentials *cli.UserCredentials
+	states          map[string]json.RawMessage
+}
+
+// Writes every checkpoint as an event of stream holding the states of all the partitions, so the states must be
+// small enough to fit in a single event. Only the last checkpoints are kept.
+func NewStreamStateStore(
+	connection cli.Connection,
+	stream string,
+	userCredentials *cli.UserCredentials,
+) StateStore {
+	if connection == nil {
+		panic("connection is nil")
+	}
+	if stream == "" {
+		panic("stream must be present")
+	}
+	return &streamStateStore{
+		connection:      connection,
+		stream:          stream,
+		userCredentials: userCredentials,
+		states:          map[string]json.RawMessage{},
+	}
+}
+
+func (s *streamStateStore) Load() (*Checkpoint, map[string]json.RawMessage, error) {
+	task, err := s.connection.ReadEventAsync(s.stream, -1, false, s.userCredentials)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := task.Error(); err != nil {
+		return nil, nil, err
+	}
+	result := task.Result().(*cli.EventReadResult)
+	switch result.Status() {
+	case cli.EventReadStatus_Success:
+	case cli.EventReadStatus_NoStream:
+		return nil, map[string]json.RawMessage{}, s.limitCheckpoints()
+	case cli.EventReadStatus_NotFound:
+		return nil, map[string]json.RawMessage{}, nil
+	default:
+		return nil, nil, fmt.Errorf("Failed reading checkpoint from %s: %s", s.stream, result.Status())
+	}
+	data := streamCheckpoint{}
+	if err := json.Unmarshal(result.Event().Event().Data(), &data); err != nil {
+		return nil, nil, err
+	}
+	if data.States != nil {
+		s.states = data.States
+	}
+	return data.Checkpoint, copyStates(s.states), nil
+}
+
+func (s *streamStateStore) limitCheckpoints() error {
+	metadata := cli.CreateStreamMetadataBuilder().SetMaxCount(checkpointsToKeep).Build()
+	task, err := s.connection.SetStreamMetadataAsync(s.stream, cli.ExpectedVersion_Any, metadata, s.userCredentials)
+	if err != nil {
+		return err
+	}
+	return task.Error()
+}
+
+func (s *streamStateStore) Save(checkpoint *Checkpoint, states map[string]json.RawMessage) error {
+	for partition, state := range states {
+		s.states[partition] = state
+	}
+	data, err := json.Marshal(streamCheckpoint{Checkpoint: checkpoint, States: s.states})
+	if err != nil {
+		return err
+	}
+	event := cli.NewEventData(uuid.Must(uuid.NewV4()), checkpointEventType, true, data, nil)
+	task, err := s.connection.AppendToStreamAsync(s.stream, cli.ExpectedVersion_Any, []*cli.EventData{event},
+		s.userCredentials)
+	if err != nil {
+		return err
+	}
+	return task.Error()
+}
+
+// Returns the stream a stream store writes its checkpoints to, or an empty string for other stores
+func checkpointStreamOf(store StateStore) string {
+	if s, ok := store.(*streamStateStore); ok {
+		return s.stream
+	}
+	return ""
+}
+
+func copyStates(states map[string]json.RawMessage) map[string]json.RawMessage {
+	result := make(map[string]json.RawMessage, len(states))
+	for partition, state := range states {
+		result[partition] = state
+	}
+	return result
+}
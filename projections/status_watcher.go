@@ -0,0 +1,143 @@
+package projections
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	cli "github.com/jdextraze/go-gesclient/client"
+	"strings"
+	"time"
+)
+
+const defaultStatusPollInterval = 500 * time.Millisecond
+
+type ProjectionFaulted struct {
+	name   string
+	reason string
+}
+
+func NewProjectionFaulted(name string, reason string) error {
+	return &ProjectionFaulted{name, reason}
+}
+
+func (e *ProjectionFaulted) Reason() string { return e.reason }
+
+func (e *ProjectionFaulted) Error() string {
+	return fmt.Sprintf("Projection '%s' faulted: %s", e.name, e.reason)
+}
+
+// Err is set on the last update when the status cannot be read or the projection faulted
+type ProjectionStatusUpdate struct {
+	Details *ProjectionDetails
+	Err     error
+}
+
+// Polls the status of a projection every interval and sends an update every time its status, state reason or
+// progress changes. The channel is closed when ctx is done, the projection completes or faults, or the status cannot
+// be read.
+func (m *Manager) WatchStatus(
+	ctx context.Context,
+	name string,
+	interval time.Duration,
+	userCredentials *cli.UserCredentials,
+) <-chan *ProjectionStatusUpdate {
+	if name == "" {
+		panic("name must be present")
+	}
+	if interval <= 0 {
+		panic("interval should be positive")
+	}
+
+	updates := make(chan *ProjectionStatusUpdate)
+	go func() {
+		defer close(updates)
+		var last *ProjectionDetails
+		for {
+			details, err := m.getStatusDetails(ctx, name, userCredentials)
+			if err == nil && isFaulted(details) {
+				err = NewProjectionFaulted(name, details.StateReason)
+			}
+			if err != nil || last == nil || details.Status != last.Status ||
+				details.StateReason != last.StateReason || details.Progress != last.Progress {
+				if ctx.Err() != nil {
+					return
+				}
+				select {
+				case updates <- &ProjectionStatusUpdate{details, err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil || isCompleted(details) {
+				return
+			}
+			last = details
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return updates
+}
+
+// Waits until a one-time or transient projection completes. Returns an error when ctx is done before or when the
+// projection faults.
+func (m *Manager) WaitForCompletion(
+	ctx context.Context,
+	name string,
+	userCredentials *cli.UserCredentials,
+) (*ProjectionDetails, error) {
+	var last *ProjectionStatusUpdate
+	for update := range m.WatchStatus(ctx, name, defaultStatusPollInterval, userCredentials) {
+		last = update
+	}
+	if last != nil && last.Err != nil {
+		return last.Details, last.Err
+	}
+	if last == nil || !isCompleted(last.Details) {
+		return nil, ctx.Err()
+	}
+	return last.Details, nil
+}
+
+// Returns ctx.Err() as soon as ctx is done, without waiting for the request to time out
+func (m *Manager) getStatusDetails(
+	ctx context.Context,
+	name string,
+	userCredentials *cli.UserCredentials,
+) (*ProjectionDetails, error) {
+	task := m.GetStatusAsync(name, userCredentials)
+	done := make(chan struct{})
+	go func() {
+		task.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if err := task.Error(); err != nil {
+		return nil, err
+	}
+	details := &ProjectionDetails{}
+	if err := json.Unmarshal([]byte(task.Result().(string)), details); err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+func isFaulted(d *ProjectionDetails) bool {
+	return strings.HasPrefix(d.Status, "Faulted")
+}
+
+// One-time projections report Completed/Stopped/Writing results while their result is written, then
+// Completed/Stopped or Stopped once done
+func isCompleted(d *ProjectionDetails) bool {
+	if strings.Contains(d.Status, "Writing results") {
+		return false
+	}
+	return strings.Contains(d.Status, "Completed") || d.Status == "Stopped" && d.Progress >= 100
+}
@@ -0,0 +1,88 @@
+package projections_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/jdextraze/go-gesclient/projections"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func serveStatuses(t *testing.T, statuses ...string) (*projections.Manager, func()) {
+	var calls int32
+	return newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		i := int(atomic.AddInt32(&calls, 1)) - 1
+		if i >= len(statuses) {
+			i = len(statuses) - 1
+		}
+		fmt.Fprintf(w, `{"name":"import",%s}`, statuses[i])
+	})
+}
+
+func TestManager_WatchStatus(t *testing.T) {
+	manager, closeServer := serveStatuses(t,
+		`"status":"Running","progress":10`,
+		`"status":"Running","progress":10`,
+		`"status":"Running","progress":60`,
+		`"status":"Completed/Stopped/Writing results","progress":100`,
+		`"status":"Completed/Stopped/Writing results","progress":100`,
+		`"status":"Completed/Stopped","progress":100`)
+	defer closeServer()
+
+	var statuses []string
+	for update := range manager.WatchStatus(context.Background(), "import", time.Millisecond, nil) {
+		if update.Err != nil {
+			t.Fatalf("Update failed: %v", update.Err)
+		}
+		statuses = append(statuses, fmt.Sprintf("%s %v", update.Details.Status, update.Details.Progress))
+	}
+	expected := "[Running 10 Running 60 Completed/Stopped/Writing results 100 Completed/Stopped 100]"
+	if fmt.Sprint(statuses) != expected {
+		t.Errorf("Statuses doesn't match: %v != %s", statuses, expected)
+	}
+}
+
+func TestManager_WaitForCompletion(t *testing.T) {
+	manager, closeServer := serveStatuses(t, `"status":"Running"`, `"status":"Stopped","progress":100`)
+	defer closeServer()
+	details, err := manager.WaitForCompletion(context.Background(), "import", nil)
+	if err != nil || details.Status != "Stopped" {
+		t.Errorf("WaitForCompletion doesn't match: %+v, %v", details, err)
+	}
+
+	manager, closeServer = serveStatuses(t, `"status":"Faulted","stateReason":"boom"`)
+	defer closeServer()
+	_, err = manager.WaitForCompletion(context.Background(), "import", nil)
+	if faulted, ok := err.(*projections.ProjectionFaulted); !ok || faulted.Reason() != "boom" {
+		t.Errorf("Error doesn't match: %v", err)
+	}
+
+	manager, closeServer = serveStatuses(t, `"status":"Running"`)
+	defer closeServer()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err = manager.WaitForCompletion(ctx, "import", nil); err != context.DeadlineExceeded {
+		t.Errorf("Error doesn't match: %v != %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestManager_WaitForCompletion_CancelDuringRequest(t *testing.T) {
+	release := make(chan struct{})
+	manager, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	})
+	defer closeServer()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := manager.WaitForCompletion(ctx, "import", nil); err != context.DeadlineExceeded {
+		t.Errorf("Error doesn't match: %v != %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("WaitForCompletion should return once ctx is done, took %v", elapsed)
+	}
+}
@@ -39,7 +39,7 @@ type catchUpSubscription struct {
 	liveQueue             chan *client.ResolvedEvent
 	subscription          client.EventStoreSubscription
 	dropData              *dropData
-	allowProcessing       bool
+	allowProcessing       int32
 	isProcessing          int32
 	shouldStop            bool
 	isDropped             int32
@@ -147,7 +147,7 @@ func (s *catchUpSubscription) loadHistoricalEvents() (interface{}, error) {
 	}
 
 	s.stopped.Add(1)
-	s.allowProcessing = true
+	atomic.StoreInt32(&s.allowProcessing, 1)
 
 	if !s.shouldStop {
 		if s.verbose {
@@ -229,7 +229,7 @@ func (s *catchUpSubscription) startLiveProcessing() error {
 		s.debug("hooking to connection.Connected")
 	}
 	s.connection.Connected().Add(s.onReconnect)
-	s.allowProcessing = true
+	atomic.StoreInt32(&s.allowProcessing, 1)
 	s.ensureProcessingPushQueue()
 	return nil
 }
@@ -238,7 +238,7 @@ func (s *catchUpSubscription) enqueueSubscriptionDropNotification(reason client.
 	dd := dropData{reason, err}
 	if atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&s.dropData)), unsafe.Pointer(nilDropReason), unsafe.Pointer(&dd)) {
 		s.liveQueue <- dropSubscriptionEvent
-		if s.allowProcessing {
+		if atomic.LoadInt32(&s.allowProcessing) == 1 {
 			s.ensureProcessingPushQueue()
 		}
 	}
@@ -270,7 +270,7 @@ func (s *catchUpSubscription) enqueuePushedEvent(s2 client.EventStoreSubscriptio
 
 	s.liveQueue <- e
 
-	if s.allowProcessing {
+	if atomic.LoadInt32(&s.allowProcessing) == 1 {
 		s.ensureProcessingPushQueue()
 	}
 	return nil
@@ -294,13 +294,14 @@ func (s *catchUpSubscription) ensureProcessingPushQueue() {
 func (s *catchUpSubscription) processLiveQueue() {
 	for e := range s.liveQueue {
 		if e == dropSubscriptionEvent {
-			if s.dropData == nilDropReason {
-				s.dropData = &dropData{
+			dd := (*dropData)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&s.dropData))))
+			if dd == nilDropReason {
+				dd = &dropData{
 					reason: client.SubscriptionDropReason_Unknown,
 					err:    errors.New("Drop reason not specified"),
 				}
 			}
-			s.dropSubscription(s.dropData.reason, s.dropData.err)
+			s.dropSubscription(dd.reason, dd.err)
 			atomic.CompareAndSwapInt32(&s.isProcessing, 1, 0)
 			break
 		}